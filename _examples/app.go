@@ -0,0 +1,60 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	ui "github.com/gizak/termui/v3"
+	"github.com/gizak/termui/v3/widgets"
+)
+
+func main() {
+	p := widgets.NewParagraph()
+	p.Title = "App"
+
+	g := widgets.NewGauge()
+	g.Title = "Progress"
+
+	grid := ui.NewGrid()
+	grid.Set(
+		ui.NewRow(0.5, p),
+		ui.NewRow(0.5, g),
+	)
+
+	app := ui.NewApp(grid)
+	app.Fullscreen = true
+
+	app.Handle("q", func(ui.Event) {
+		app.Stop()
+	})
+	app.Handle("<C-c>", func(ui.Event) {
+		app.Stop()
+	})
+	app.Handle("", func(e ui.Event) {
+		p.Text = fmt.Sprintf("Last event: %s\nPress q to quit.", e.ID)
+	})
+	app.Every(100*time.Millisecond, func() {
+		g.Percent = (g.Percent + 1) % 101
+	})
+
+	// redraws can also be requested from other goroutines
+	go func() {
+		for range time.Tick(time.Second) {
+			g.Lock()
+			g.Label = time.Now().Format("15:04:05")
+			g.Unlock()
+			app.Redraw()
+		}
+	}()
+
+	if err := app.Run(); err != nil {
+		log.Fatalf("failed to run termui: %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+
+	ui "github.com/gizak/termui/v3"
+	"github.com/gizak/termui/v3/widgets"
+)
+
+func main() {
+	sidebar := widgets.NewList()
+	sidebar.Title = "Sidebar"
+	sidebar.Rows = []string{"30 columns", "shrinks to 20"}
+
+	cards := ui.NewFlex(ui.FlexRow)
+	cards.Wrap = true
+	cards.Gap = 1
+	for i := 1; i <= 7; i++ {
+		p := widgets.NewParagraph()
+		p.Title = fmt.Sprintf("Card %d", i)
+		p.Text = "Wraps and grows"
+		cards.Add(&ui.FlexItem{Drawable: p, Basis: 20, Grow: 1})
+	}
+
+	body := ui.NewFlex(ui.FlexRow)
+	body.Gap = 1
+	body.Add(
+		&ui.FlexItem{Drawable: sidebar, Basis: 30, Shrink: 1, MinSize: 20},
+		&ui.FlexItem{Drawable: cards, Grow: 1},
+	)
+
+	status := widgets.NewParagraph()
+	status.Text = "Resize the terminal to lay out again. Press q to quit."
+	status.Border = false
+
+	root := ui.NewFlex(ui.FlexColumn)
+	root.PaddingLeft = 1
+	root.PaddingRight = 1
+	root.Add(
+		&ui.FlexItem{Drawable: body, Grow: 1},
+		&ui.FlexItem{Drawable: status, Basis: 1},
+	)
+
+	app := ui.NewApp(root)
+	app.Fullscreen = true
+	app.Handle("q", func(ui.Event) {
+		app.Stop()
+	})
+	app.Handle("<C-c>", func(ui.Event) {
+		app.Stop()
+	})
+
+	if err := app.Run(); err != nil {
+		log.Fatalf("failed to run termui: %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+
+	ui "github.com/gizak/termui/v3"
+	"github.com/gizak/termui/v3/widgets"
+)
+
+type nodeValue string
+
+func (nv nodeValue) String() string {
+	return string(nv)
+}
+
+func main() {
+	tabs := widgets.NewTabPane("first", "second", "third")
+	tabs.Title = "Tabs"
+
+	l := widgets.NewList()
+	l.Title = "List"
+	l.SelectedRowStyle = ui.NewStyle(ui.ColorYellow)
+	for i := 0; i < 30; i++ {
+		l.Rows = append(l.Rows, fmt.Sprintf("row %d", i))
+	}
+
+	t := widgets.NewTable()
+	t.Title = "Table"
+	t.SelectedRowStyle = ui.NewStyle(ui.ColorYellow)
+	t.RowSeparator = false
+	for i := 0; i < 30; i++ {
+		t.Rows = append(t.Rows, []string{fmt.Sprintf("row %d", i), fmt.Sprintf("%d", i*i)})
+	}
+
+	tree := widgets.NewTree()
+	tree.Title = "Tree"
+	tree.SelectedRowStyle = ui.NewStyle(ui.ColorYellow)
+	tree.SetNodes([]*widgets.TreeNode{
+		{
+			Value: nodeValue("Key 1"),
+			Nodes: []*widgets.TreeNode{
+				{Value: nodeValue("Key 1.1")},
+				{Value: nodeValue("Key 1.2")},
+			},
+		},
+		{Value: nodeValue("Key 2")},
+	})
+
+	grid := ui.NewGrid()
+	grid.Set(
+		ui.NewRow(0.2, tabs),
+		ui.NewRow(0.8,
+			ui.NewCol(1.0/3, l),
+			ui.NewCol(1.0/3, t),
+			ui.NewCol(1.0/3, tree),
+		),
+	)
+
+	app := ui.NewApp(grid)
+	app.Fullscreen = true
+	// Tab, Shift+Tab and clicking move focus, and the focused widget handles the other keys
+	app.Focus = ui.NewFocusChain(tabs, l, t, tree)
+	app.Handle("q", func(ui.Event) {
+		app.Stop()
+	})
+
+	if err := app.Run(); err != nil {
+		log.Fatalf("failed to run termui: %v", err)
+	}
+}
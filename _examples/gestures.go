@@ -0,0 +1,74 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+
+	ui "github.com/gizak/termui/v3"
+	"github.com/gizak/termui/v3/widgets"
+)
+
+// splitter lays out two widgets side by side, with the split at a column that can be dragged.
+type splitter struct {
+	ui.Block
+	left, right ui.Drawable
+	split       int
+}
+
+func (self *splitter) Draw(buf *ui.Buffer) {
+	if self.split <= self.Min.X || self.split >= self.Max.X {
+		self.split = (self.Min.X + self.Max.X) / 2
+	}
+	self.left.SetRect(self.Min.X, self.Min.Y, self.split, self.Max.Y)
+	self.right.SetRect(self.split, self.Min.Y, self.Max.X, self.Max.Y)
+	for _, item := range self.Children() {
+		item.Lock()
+		item.Draw(buf.Sub(item.GetRect()))
+		item.Unlock()
+	}
+}
+
+func (self *splitter) Children() []ui.Drawable {
+	return []ui.Drawable{self.left, self.right}
+}
+
+func main() {
+	left := widgets.NewParagraph()
+	left.Title = "Left"
+	left.Text = "Drag the mouse to move the split.\nDouble click to reset it.\nPress q to quit."
+
+	right := widgets.NewParagraph()
+	right.Title = "Right"
+
+	s := &splitter{Block: *ui.NewBlock(), left: left, right: right}
+
+	app := ui.NewApp(s)
+	app.Fullscreen = true
+	app.Gestures = ui.NewGestures()
+
+	app.Handle("<MouseDragMove>", func(e ui.Event) {
+		drag := e.Payload.(ui.MouseDrag)
+		s.split = drag.X
+		right.Text = fmt.Sprintf("dragging from %d,%d to %d,%d", drag.StartX, drag.StartY, drag.X, drag.Y)
+	})
+	app.Handle("<MouseDragEnd>", func(e ui.Event) {
+		right.Text = "drag ended"
+	})
+	app.Handle("<MouseDoubleClick>", func(e ui.Event) {
+		s.split = (s.Min.X + s.Max.X) / 2
+		right.Text = "split reset"
+	})
+	app.Handle("q", func(ui.Event) {
+		app.Stop()
+	})
+
+	if err := app.Run(); err != nil {
+		log.Fatalf("failed to run termui: %v", err)
+	}
+}
@@ -66,7 +66,7 @@ func main() {
 	ls.Border = false
 
 	p := widgets.NewParagraph()
-	p.Text = "<> This row has 3 columns\n<- Widgets can be stacked up like left side\n<- Stacked widgets are treated as a single widget"
+	p.Text = "<> This row has 3 columns: 30 cells, 25% and the rest\n<- Widgets can be stacked up like left side\n<- Stacked widgets are treated as a single widget"
 	p.Title = "Demonstration"
 
 	grid := ui.NewGrid()
@@ -79,13 +79,13 @@ func main() {
 			ui.NewCol(1.0/2, lc),
 		),
 		ui.NewRow(1.0/2,
-			ui.NewCol(1.0/4, ls),
-			ui.NewCol(1.0/4,
+			ui.NewColSize(ui.FixedSize(30), ls),
+			ui.NewColSize(ui.PercentSize(25).WithMin(20),
 				ui.NewRow(.9/3, gs[0]),
 				ui.NewRow(.9/3, gs[1]),
 				ui.NewRow(1.2/3, gs[2]),
 			),
-			ui.NewCol(1.0/2, p),
+			ui.NewColSize(ui.FillSize(1), p),
 		),
 	)
 
@@ -43,7 +43,9 @@ func main() {
 		images = append(images, image)
 	}
 
-	if err := ui.Init(); err != nil {
+	// the images are drawn in truecolor if the terminal supports it, at the cost of drawing the basic colors
+	// with the default Xterm palette instead of the terminal's theme
+	if err := ui.InitWithBackend(&ui.TermboxBackend{ColorDepth: ui.DetectColorDepth()}); err != nil {
 		log.Fatalf("failed to initialize termui: %v", err)
 	}
 	defer ui.Close()
@@ -53,7 +55,9 @@ func main() {
 	index := 0
 	render := func() {
 		img.Image = images[index]
-		if !img.Monochrome {
+		if !img.Monochrome && img.TrueColor {
+			img.Title = fmt.Sprintf("TrueColor %d/%d", index+1, len(images))
+		} else if !img.Monochrome {
 			img.Title = fmt.Sprintf("Color %d/%d", index+1, len(images))
 		} else if !img.MonochromeInvert {
 			img.Title = fmt.Sprintf("Monochrome(%d) %d/%d", img.MonochromeThreshold, index+1, len(images))
@@ -82,6 +86,8 @@ func main() {
 			img.Monochrome = !img.Monochrome
 		case "<Tab>":
 			img.MonochromeInvert = !img.MonochromeInvert
+		case "t":
+			img.TrueColor = !img.TrueColor
 		}
 		render()
 	}
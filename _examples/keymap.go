@@ -0,0 +1,63 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	"log"
+
+	ui "github.com/gizak/termui/v3"
+	"github.com/gizak/termui/v3/widgets"
+)
+
+func main() {
+	l := widgets.NewList()
+	l.Title = "List"
+	for i := 0; i < 50; i++ {
+		l.Rows = append(l.Rows, fmt.Sprintf("[%d] item", i))
+	}
+
+	help := widgets.NewParagraph()
+	help.Title = "Help"
+
+	grid := ui.NewGrid()
+	grid.Set(
+		ui.NewCol(0.5, l),
+		ui.NewCol(0.5, help),
+	)
+
+	app := ui.NewApp(grid)
+	app.Fullscreen = true
+
+	km := ui.NewKeymap()
+	setMode := func(mode string) {
+		km.SetMode(mode)
+		help.Text = fmt.Sprintf("Mode: %s\n\n%s", mode, km.Help())
+	}
+	km.Bind("normal", "j", "scroll down", func(ui.Event) { l.ScrollDown() })
+	km.Bind("normal", "k", "scroll up", func(ui.Event) { l.ScrollUp() })
+	km.Bind("normal", "gg", "go to top", func(ui.Event) { l.ScrollTop() })
+	km.Bind("normal", "G", "go to bottom", func(ui.Event) { l.ScrollBottom() })
+	km.Bind("normal", "dd", "delete row", func(ui.Event) {
+		if len(l.Rows) > 0 {
+			l.Rows = append(l.Rows[:l.SelectedRow], l.Rows[l.SelectedRow+1:]...)
+			if l.SelectedRow >= len(l.Rows) && l.SelectedRow > 0 {
+				l.SelectedRow--
+			}
+		}
+	})
+	km.Bind("normal", "i", "insert mode", func(ui.Event) { setMode("insert") })
+	km.Bind("normal", "q", "quit", func(ui.Event) { app.Stop() })
+	km.Bind("insert", "<Escape>", "normal mode", func(ui.Event) { setMode("normal") })
+	km.Bind("", "<C-c>", "quit", func(ui.Event) { app.Stop() })
+	setMode("normal")
+	app.Keymap = km
+
+	if err := app.Run(); err != nil {
+		log.Fatalf("failed to run termui: %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"log"
+
+	ui "github.com/gizak/termui/v3"
+	"github.com/gizak/termui/v3/widgets"
+)
+
+func main() {
+	if err := ui.Init(); err != nil {
+		log.Fatalf("failed to initialize termui: %v", err)
+	}
+	defer ui.Close()
+
+	p := widgets.NewParagraph()
+	p.Title = "Dashboard"
+	p.Text = "Press q to quit, or <Escape> to open and close a tooltip."
+
+	l := widgets.NewList()
+	l.Title = "List"
+	l.Rows = []string{"foo", "bar", "baz"}
+
+	grid := ui.NewGrid()
+	termWidth, termHeight := ui.TerminalDimensions()
+	grid.SetRect(0, 0, termWidth, termHeight)
+	grid.Set(
+		ui.NewRow(1.0,
+			ui.NewCol(0.5, p),
+			ui.NewCol(0.5, l),
+		),
+	)
+
+	tooltip := widgets.NewParagraph()
+	tooltip.Text = "A tooltip"
+	tooltip.SetRect(2, 3, 15, 6)
+
+	confirm := widgets.NewParagraph()
+	confirm.Title = "Quit?"
+	confirm.Text = "Press y to quit, or n to go back."
+
+	layers := ui.NewLayers()
+	layers.SetRect(0, 0, termWidth, termHeight)
+	layers.Add(0, grid)
+
+	ui.Render(layers)
+
+	uiEvents := ui.PollEvents()
+	for {
+		e := <-uiEvents
+		switch {
+		case layers.TopOverlay() == confirm:
+			switch e.ID {
+			case "y":
+				return
+			case "n", "<Escape>":
+				layers.PopOverlay()
+			}
+		case e.ID == "q" || e.ID == "<C-c>":
+			layers.PushModal(confirm, 40, 5)
+		case e.ID == "<Escape>":
+			if layers.TopOverlay() == tooltip {
+				layers.PopOverlay()
+			} else {
+				layers.PushOverlay(tooltip)
+			}
+		case e.ID == "<Resize>":
+			payload := e.Payload.(ui.Resize)
+			layers.SetRect(0, 0, payload.Width, payload.Height)
+			grid.SetRect(0, 0, payload.Width, payload.Height)
+		}
+		ui.Render(layers)
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build ignore
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	ui "github.com/gizak/termui/v3"
+	"github.com/gizak/termui/v3/widgets"
+)
+
+// Run with `-record session.jsonl`, press j and k a few times and quit with q,
+// then run with `-replay session.jsonl` to print the screen at the end of the session.
+func main() {
+	record := flag.String("record", "", "file to record the session to")
+	replay := flag.String("replay", "", "file to replay a session from")
+	flag.Parse()
+
+	l := widgets.NewList()
+	l.Title = "List"
+	l.SelectedRowStyle = ui.NewStyle(ui.ColorYellow)
+	for i := 0; i < 20; i++ {
+		l.Rows = append(l.Rows, fmt.Sprintf("row %d", i))
+	}
+
+	app := ui.NewApp(l)
+	app.Fullscreen = true
+	app.Handle("j", func(ui.Event) { l.ScrollDown() })
+	app.Handle("k", func(ui.Event) { l.ScrollUp() })
+	app.Handle("q", func(ui.Event) { app.Stop() })
+
+	var screen *ui.HeadlessBackend
+	switch {
+	case *record != "":
+		f, err := os.Create(*record)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		app.Recorder = ui.NewRecorder(f)
+	case *replay != "":
+		f, err := os.Open(*replay)
+		if err != nil {
+			log.Fatal(err)
+		}
+		recording, err := ui.ReadRecording(f)
+		f.Close()
+		if err != nil {
+			log.Fatal(err)
+		}
+		screen = recording.NewScreen()
+		app.Backend = screen
+		go recording.Replay(screen, 0)
+	}
+
+	if err := app.Run(); err != nil {
+		log.Fatalf("failed to run termui: %v", err)
+	}
+	if screen != nil {
+		fmt.Print(screen.Snapshot())
+	}
+}
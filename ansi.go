@@ -0,0 +1,178 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	ansiEscape = '\x1b'
+	ansiBell   = '\a'
+)
+
+// ParseANSI parses a string containing ANSI escape sequences, such as the colored output of other
+// command line tools, and returns []Cell with the correct styling.
+// Uses defaultStyle for any text without a style and whenever the style is reset.
+// SGR sequences for 16, 256 and 24-bit colors and all Modifiers are supported.
+// Other escape sequences are removed.
+func ParseANSI(s string, defaultStyle Style) []Cell {
+	cells := []Cell{}
+	runes := []rune(s)
+	style := defaultStyle
+
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != ansiEscape {
+			cells = append(cells, Cell{runes[i], style})
+			continue
+		}
+		if i+1 == len(runes) {
+			break
+		}
+		switch runes[i+1] {
+		case '[':
+			// CSI: parameter and intermediate bytes, terminated by a byte in '@'..'~'
+			end := i + 2
+			for end < len(runes) && (runes[end] < '@' || runes[end] > '~') {
+				end++
+			}
+			if end == len(runes) {
+				return cells
+			}
+			if runes[end] == 'm' {
+				style = readSGR(string(runes[i+2:end]), style, defaultStyle)
+			}
+			i = end
+		case ']':
+			// OSC: terminated by BEL or ESC \
+			end := i + 2
+			for end < len(runes) && runes[end] != ansiBell && !(runes[end] == ansiEscape && end+1 < len(runes) && runes[end+1] == '\\') {
+				end++
+			}
+			if end < len(runes) && runes[end] == ansiEscape {
+				end++
+			}
+			i = end
+		default:
+			// two character escape sequence
+			i++
+		}
+	}
+
+	return cells
+}
+
+// readSGRCodes reads the parameters of a Select Graphic Rendition sequence, which are separated by `;`, or by
+// `:` within an extended color. Empty parameters mean 0. The color space of a 24-bit color like `38:2::r:g:b`
+// is dropped, so that extended colors read the same with both separators.
+func readSGRCodes(params string) ([]int, bool) {
+	codes := []int{}
+	for _, param := range strings.Split(params, ";") {
+		subparams := strings.Split(param, ":")
+		sub := make([]int, len(subparams))
+		for i, subparam := range subparams {
+			if subparam == "" {
+				continue
+			}
+			code, err := strconv.Atoi(subparam)
+			if err != nil {
+				return nil, false
+			}
+			sub[i] = code
+		}
+		if len(sub) == 6 && (sub[0] == 38 || sub[0] == 48) && sub[1] == 2 {
+			sub = append(sub[:2], sub[3:]...)
+		}
+		codes = append(codes, sub...)
+	}
+	return codes, true
+}
+
+// readSGR applies the parameters of a Select Graphic Rendition sequence like `1;38;5;208` to a style.
+func readSGR(params string, style Style, defaultStyle Style) Style {
+	codes, ok := readSGRCodes(params)
+	if !ok {
+		return style
+	}
+
+	for i := 0; i < len(codes); i++ {
+		code := codes[i]
+		switch {
+		case code == 0:
+			style = defaultStyle
+		case code == 1:
+			style.Modifier |= ModifierBold
+		case code == 2:
+			style.Modifier |= ModifierDim
+		case code == 3:
+			style.Modifier |= ModifierItalic
+		case code == 4:
+			style.Modifier |= ModifierUnderline
+		case code == 5 || code == 6:
+			style.Modifier |= ModifierBlink
+		case code == 7:
+			style.Modifier |= ModifierReverse
+		case code == 8:
+			style.Modifier |= ModifierHidden
+		case code == 9:
+			style.Modifier |= ModifierStrikethrough
+		case code == 21:
+			style.Modifier |= ModifierDoubleUnderline
+		case code == 22:
+			style.Modifier &^= ModifierBold | ModifierDim
+		case code == 23:
+			style.Modifier &^= ModifierItalic
+		case code == 24:
+			style.Modifier &^= ModifierUnderline | ModifierDoubleUnderline
+		case code == 25:
+			style.Modifier &^= ModifierBlink
+		case code == 27:
+			style.Modifier &^= ModifierReverse
+		case code == 28:
+			style.Modifier &^= ModifierHidden
+		case code == 29:
+			style.Modifier &^= ModifierStrikethrough
+		case code >= 30 && code <= 37:
+			style.Fg = Color(code - 30)
+		case code == 38:
+			style.Fg, i = readSGRColor(codes, i, style.Fg)
+		case code == 39:
+			style.Fg = defaultStyle.Fg
+		case code >= 40 && code <= 47:
+			style.Bg = Color(code - 40)
+		case code == 48:
+			style.Bg, i = readSGRColor(codes, i, style.Bg)
+		case code == 49:
+			style.Bg = defaultStyle.Bg
+		case code >= 90 && code <= 97:
+			style.Fg = Color(code - 90 + 8)
+		case code >= 100 && code <= 107:
+			style.Bg = Color(code - 100 + 8)
+		}
+	}
+
+	return style
+}
+
+// readSGRColor reads an extended color following the 38 or 48 code at codes[i], which is either
+// `5;<n>` for an Xterm color or `2;<r>;<g>;<b>` for a 24-bit color.
+// Returns the color, or the given one if it is malformed, and the index of the last code read.
+func readSGRColor(codes []int, i int, color Color) (Color, int) {
+	if i+1 >= len(codes) {
+		return color, i
+	}
+	switch codes[i+1] {
+	case 5:
+		if i+2 < len(codes) && codes[i+2] >= 0 && codes[i+2] < 256 {
+			return Color(codes[i+2]), i + 2
+		}
+	case 2:
+		if i+4 < len(codes) {
+			return NewRGBColor(uint8(codes[i+2]), uint8(codes[i+3]), uint8(codes[i+4])), i + 4
+		}
+	}
+	return color, len(codes)
+}
@@ -0,0 +1,72 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseANSI(t *testing.T) {
+	red := NewStyle(ColorRed)
+	orange := NewStyle(NewRGBColor(255, 136, 0))
+	bold := NewStyle(ColorClear, ColorClear, ModifierBold)
+	tests := []struct {
+		input string
+		want  []span
+	}{
+		{"plain", []span{{"plain", StyleClear}}},
+		// 16 colors
+		{"\x1b[31mred\x1b[0m plain", []span{{"red", red}, {" plain", StyleClear}}},
+		{"\x1b[91;102mx", []span{{"x", NewStyle(Color(9), Color(10))}}},
+		{"\x1b[37;44mx", []span{{"x", NewStyle(ColorWhite, ColorBlue)}}},
+		// 256 colors
+		{"\x1b[38;5;208mx", []span{{"x", NewStyle(Color(208))}}},
+		{"\x1b[48:5:17mx", []span{{"x", NewStyle(ColorClear, Color(17))}}},
+		// 24-bit colors
+		{"\x1b[38;2;255;136;0mx", []span{{"x", orange}}},
+		{"\x1b[38:2:255:136:0mx", []span{{"x", orange}}},
+		{"\x1b[38:2::255:136:0mx", []span{{"x", orange}}},
+		{"\x1b[1;38;2;255;136;0;4mx", []span{{"x", NewStyle(orange.Fg, ColorClear, ModifierBold|ModifierUnderline)}}},
+		// malformed extended colors keep the current color
+		{"\x1b[31;38;5mx", []span{{"x", red}}},
+		{"\x1b[31;38;5;300mx", []span{{"x", red}}},
+		// resets
+		{"\x1b[1;31mx\x1b[22my\x1b[39mz", []span{{"x", NewStyle(ColorRed, ColorClear, ModifierBold)}, {"y", red}, {"z", StyleClear}}},
+		{"\x1b[31mx\x1b[my", []span{{"x", red}, {"y", StyleClear}}},
+		{"\x1b[31mx\x1b[;1my", []span{{"x", red}, {"y", bold}}},
+		{"\x1b[31mx\x1b[1;;4my", []span{{"x", red}, {"y", NewStyle(ColorClear, ColorClear, ModifierUnderline)}}},
+		// other escape sequences are removed
+		{"\x1b[2J\x1b[1;1Hx", []span{{"x", StyleClear}}},
+		{"\x1b]0;title\x07x", []span{{"x", StyleClear}}},
+		{"\x1b]8;;http://x\x1b\\link\x1b]8;;\x1b\\", []span{{"link", StyleClear}}},
+		{"\x1b(Bx", []span{{"Bx", StyleClear}}},
+		// truncated sequences
+		{"x\x1b", []span{{"x", StyleClear}}},
+		{"x\x1b[31", []span{{"x", StyleClear}}},
+		{"x\x1b]0;title", []span{{"x", StyleClear}}},
+	}
+	for _, test := range tests {
+		got := spans(ParseANSI(test.input, StyleClear))
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("ParseANSI(%q) = %v, want %v", test.input, got, test.want)
+		}
+	}
+}
+
+func TestParseANSIDefaultStyle(t *testing.T) {
+	defaultStyle := NewStyle(ColorWhite, ColorBlack)
+	got := spans(ParseANSI("a\x1b[31mb\x1b[0mc\x1b[41md\x1b[49me", defaultStyle))
+	want := []span{
+		{"a", defaultStyle},
+		{"b", NewStyle(ColorRed, ColorBlack)},
+		{"c", defaultStyle},
+		{"d", NewStyle(ColorWhite, ColorRed)},
+		{"e", defaultStyle},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"image"
+	"sync"
+	"time"
+)
+
+// App runs the main loop of a termui program. It initializes termui, renders Root, calls handlers for
+// events from PollEvents and for periodic tickers, and renders Root again after each of them.
+// Mouse events are then sent to the Drawables of Root under the cursor with DispatchMouse.
+// Handlers and tickers are all called from the goroutine running `Run`, so they do not need to
+// synchronize with each other.
+type App struct {
+	// Root is rendered on every redraw. It is usually a Grid or Layers.
+	Root Drawable
+	// Fullscreen sizes Root to the terminal when the App starts and whenever the terminal is resized.
+	Fullscreen bool
+	// Backend is passed to InitWithBackend. If it is nil, termbox-go is used.
+	Backend Backend
+	// Focus, if set, is given keyboard events after the handlers registered with Handle.
+	// Clicking a Focusable in Root gives it focus. While Root is Layers with a modal overlay, keyboard
+	// events are given to the overlay instead, if it is an EventHandler.
+	Focus *FocusChain
+	// Recorder, if set, records the events from PollEvents, before Gestures, so that the session can be
+	// replayed on a HeadlessBackend given as Backend.
+	Recorder *Recorder
+	// Gestures, if set, recognizes drags and multiple clicks in the events from PollEvents. The gesture
+	// events are handled like any other event.
+	Gestures *Gestures
+	// Keymap, if set, is given the events that Focus does not handle. Its pending keys are flushed
+	// from the App goroutine when they time out.
+	Keymap *Keymap
+
+	handlers map[string][]func(Event)
+	tickers  []appTicker
+	ticks    chan func()
+	redraw   chan struct{}
+	stop     chan struct{}
+	running  bool
+	stopOnce sync.Once
+	lock     sync.Mutex
+}
+
+type appTicker struct {
+	interval time.Duration
+	fn       func()
+}
+
+func NewApp(root Drawable) *App {
+	return &App{
+		Root:     root,
+		handlers: make(map[string][]func(Event)),
+		ticks:    make(chan func()),
+		redraw:   make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+	}
+}
+
+// Handle registers a handler for events with the given ID, like "q", "<C-c>" or "<Resize>".
+// Handlers registered with an empty ID are called for every event, before the others.
+// Root is redrawn after the handlers of an event are called.
+func (self *App) Handle(id string, handler func(Event)) {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	self.handlers[id] = append(self.handlers[id], handler)
+}
+
+// Every calls fn each time the interval elapses while the App is running, then redraws Root.
+func (self *App) Every(interval time.Duration, fn func()) {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	ticker := appTicker{interval, fn}
+	self.tickers = append(self.tickers, ticker)
+	if self.running {
+		go self.runTicker(ticker)
+	}
+}
+
+func (self *App) runTicker(ticker appTicker) {
+	t := time.NewTicker(ticker.interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			select {
+			case self.ticks <- ticker.fn:
+			case <-self.stop:
+				return
+			}
+		case <-self.stop:
+			return
+		}
+	}
+}
+
+// Redraw requests that Root is rendered again. It can be called from any goroutine and does not block.
+// Requests made before the App gets to render are coalesced into a single render.
+func (self *App) Redraw() {
+	select {
+	case self.redraw <- struct{}{}:
+	default:
+	}
+}
+
+// Stop makes Run return after the current handler. It can be called from any goroutine.
+func (self *App) Stop() {
+	self.stopOnce.Do(func() {
+		close(self.stop)
+	})
+}
+
+// Run initializes termui and runs the App until Stop is called, then closes termui.
+// It also returns once termui is closed, since no more events can arrive. An App cannot be run again.
+func (self *App) Run() error {
+	backend := self.Backend
+	if backend == nil {
+		backend = NewTermboxBackend()
+	}
+	if err := InitWithBackend(backend); err != nil {
+		return err
+	}
+	defer Close()
+	// stops the tickers, including when termui was closed by a handler or a signal
+	defer self.Stop()
+	defer func() {
+		self.lock.Lock()
+		self.running = false
+		self.lock.Unlock()
+	}()
+
+	if self.Fullscreen {
+		width, height := backend.Size()
+		self.Root.SetRect(0, 0, width, height)
+	}
+
+	self.lock.Lock()
+	self.running = true
+	for _, ticker := range self.tickers {
+		go self.runTicker(ticker)
+	}
+	self.lock.Unlock()
+
+	events := PollEvents()
+	if self.Recorder != nil {
+		events = self.Recorder.Wrap(events)
+	}
+	if self.Gestures != nil {
+		events = self.Gestures.Wrap(events)
+	}
+	self.Redraw()
+	for {
+		var keymapTimeout <-chan time.Time
+		if self.Keymap != nil {
+			if deadline, ok := self.Keymap.Deadline(); ok {
+				keymapTimeout = time.After(time.Until(deadline))
+			}
+		}
+		select {
+		case <-self.stop:
+			return nil
+		case e, ok := <-events:
+			if !ok {
+				// termui was closed, by a handler or a signal
+				return nil
+			}
+			self.handleEvent(e)
+			self.Redraw()
+		case <-keymapTimeout:
+			self.Keymap.Flush()
+			self.Redraw()
+		case fn := <-self.ticks:
+			fn()
+			self.Redraw()
+		case <-self.redraw:
+			Render(self.Root)
+		}
+	}
+}
+
+func (self *App) handleEvent(e Event) {
+	if resize, ok := e.Payload.(Resize); ok && self.Fullscreen {
+		self.Root.SetRect(0, 0, resize.Width, resize.Height)
+	}
+
+	self.lock.Lock()
+	handlers := append([]func(Event){}, self.handlers[""]...)
+	if e.ID != "" {
+		handlers = append(handlers, self.handlers[e.ID]...)
+	}
+	self.lock.Unlock()
+
+	for _, handler := range handlers {
+		handler(e)
+	}
+	if e.Type == MouseEvent {
+		if self.Focus != nil && e.ID == "<MouseLeft>" {
+			if mouse, ok := e.Payload.(Mouse); ok && !mouse.Drag {
+				if item, ok := HitTest(image.Pt(mouse.X, mouse.Y), self.Root).(Focusable); ok {
+					self.Focus.Focus(item)
+				}
+			}
+		}
+		DispatchMouse(e, self.Root)
+		return
+	}
+	var modal Drawable
+	if layers, ok := self.Root.(*Layers); ok {
+		modal = layers.Modal()
+	}
+	if modal != nil {
+		if handleModal(modal, e) {
+			return
+		}
+	} else if self.Focus != nil && self.Focus.HandleEvent(e) {
+		return
+	}
+	if self.Keymap != nil {
+		self.Keymap.Dispatch(e)
+	}
+}
+
+// handleModal gives a keyboard event to a modal overlay. Returns whether it was handled.
+func handleModal(modal Drawable, e Event) bool {
+	handler, ok := modal.(EventHandler)
+	if !ok || e.Type != KeyboardEvent {
+		return false
+	}
+	modal.Lock()
+	defer modal.Unlock()
+	return handler.HandleEvent(e)
+}
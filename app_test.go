@@ -0,0 +1,71 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestAppRunReturnsAfterClose(t *testing.T) {
+	screen := NewHeadlessBackend(10, 5)
+	app := NewApp(NewBlock())
+	app.Backend = screen
+	calls := 0
+	app.Handle("", func(Event) {
+		calls++
+		Close()
+	})
+	screen.PostEvent(Event{Type: KeyboardEvent, ID: "q"})
+
+	result := make(chan error)
+	go func() {
+		result <- app.Run()
+	}()
+	select {
+	case err := <-result:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		app.Stop()
+		t.Fatal("Run did not return after Close")
+	}
+	if calls != 1 {
+		t.Errorf("handler called %d times, want 1", calls)
+	}
+}
+
+func TestAppTickersStopAfterClose(t *testing.T) {
+	goroutines := runtime.NumGoroutine()
+	screen := NewHeadlessBackend(10, 5)
+	app := NewApp(NewBlock())
+	app.Backend = screen
+	ticks := 0
+	app.Every(time.Millisecond, func() {
+		// closes termui while the ticker keeps sending ticks
+		ticks++
+		if ticks == 1 {
+			Close()
+		}
+	})
+	if err := app.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	// a ticker added after Run returned is never started
+	app.Every(time.Millisecond, func() {})
+	if app.running {
+		t.Error("App is still running after Run returned")
+	}
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > goroutines {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d goroutines are left running after Run returned", runtime.NumGoroutine()-goroutines)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -5,31 +5,77 @@
 package termui
 
 import (
-	tb "github.com/nsf/termbox-go"
+	"image"
 )
 
+// Backend is the terminal layer that termui draws to and reads events from.
+// termbox-go is used by default, but any implementation can be selected with `InitWithBackend`.
+type Backend interface {
+	// Init prepares the terminal for drawing and reading events.
+	Init() error
+	// Close restores the terminal to its original state.
+	Close()
+	// Size returns the width and height of the terminal in cells.
+	Size() (int, int)
+	// SetCell sets the cell at the given position in the back buffer.
+	SetCell(x, y int, c Cell)
+	// Flush writes the back buffer to the terminal.
+	Flush() error
+	// PollEvent blocks until the next event is available. Once Close is called, it should return
+	// promptly; the event it returns then is discarded.
+	PollEvent() Event
+}
+
+var backend Backend
+
 // Init initializes termbox-go and is required to render anything.
 // After initialization, the library must be finalized with `Close`.
 func Init() error {
-	if err := tb.Init(); err != nil {
+	return InitWithBackend(NewTermboxBackend())
+}
+
+// InitWithBackend is like `Init`, but draws to and reads events from the given Backend.
+// If the TERMUI_CAST environment variable is set, the rendered output is recorded to the asciicast file it
+// names until Close.
+func InitWithBackend(b Backend) error {
+	if err := b.Init(); err != nil {
 		return err
 	}
-	tb.SetInputMode(tb.InputEsc | tb.InputMouse)
-	tb.SetOutputMode(tb.Output256)
+	backend = b
+	Invalidate()
+	startPoller(b)
+	startCastFromEnv()
 	return nil
 }
 
-// Close closes termbox-go.
+// Close closes the current backend and the channels returned by PollEvents.
 func Close() {
-	tb.Close()
+	stopCastFromEnv()
+	stopPoller()
+	if backend != nil {
+		// Close can be called by a signal while another goroutine renders
+		frame.Lock()
+		backend.Close()
+		frame.Unlock()
+	}
 }
 
 func TerminalDimensions() (int, int) {
-	tb.Sync()
-	width, height := tb.Size()
-	return width, height
+	return backend.Size()
 }
 
+// Clear clears the terminal with the default background color on the next flush.
 func Clear() {
-	tb.Clear(tb.ColorDefault, tb.Attribute(Theme.Default.Bg+1))
+	frame.Lock()
+	defer frame.Unlock()
+	if frame.stale {
+		resetFrame()
+		frame.stale = false
+	}
+	cell := Cell{' ', NewStyle(ColorClear, Theme.Default.Bg)}
+	for y := frame.Min.Y; y < frame.Max.Y; y++ {
+		for x := frame.Min.X; x < frame.Max.X; x++ {
+			setFrameCell(image.Pt(x, y), cell)
+		}
+	}
 }
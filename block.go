@@ -17,6 +17,10 @@ type Block struct {
 	Border      bool
 	BorderStyle Style
 
+	// Focused is set by FocusChain while the Block has focus. The border is then drawn with FocusedBorderStyle.
+	Focused            bool
+	FocusedBorderStyle Style
+
 	BorderLeft, BorderRight, BorderTop, BorderBottom bool
 
 	PaddingLeft, PaddingRight, PaddingTop, PaddingBottom int
@@ -39,13 +43,19 @@ func NewBlock() *Block {
 		BorderTop:    true,
 		BorderBottom: true,
 
+		FocusedBorderStyle: Theme.Block.FocusedBorder,
+
 		TitleStyle: Theme.Block.Title,
 	}
 }
 
 func (self *Block) drawBorder(buf *Buffer) {
-	verticalCell := Cell{VERTICAL_LINE, self.BorderStyle}
-	horizontalCell := Cell{HORIZONTAL_LINE, self.BorderStyle}
+	style := self.BorderStyle
+	if self.Focused {
+		style = self.FocusedBorderStyle
+	}
+	verticalCell := Cell{VERTICAL_LINE, style}
+	horizontalCell := Cell{HORIZONTAL_LINE, style}
 
 	// draw lines
 	if self.BorderTop {
@@ -63,16 +73,16 @@ func (self *Block) drawBorder(buf *Buffer) {
 
 	// draw corners
 	if self.BorderTop && self.BorderLeft {
-		buf.SetCell(Cell{TOP_LEFT, self.BorderStyle}, self.Min)
+		buf.SetCell(Cell{TOP_LEFT, style}, self.Min)
 	}
 	if self.BorderTop && self.BorderRight {
-		buf.SetCell(Cell{TOP_RIGHT, self.BorderStyle}, image.Pt(self.Max.X-1, self.Min.Y))
+		buf.SetCell(Cell{TOP_RIGHT, style}, image.Pt(self.Max.X-1, self.Min.Y))
 	}
 	if self.BorderBottom && self.BorderLeft {
-		buf.SetCell(Cell{BOTTOM_LEFT, self.BorderStyle}, image.Pt(self.Min.X, self.Max.Y-1))
+		buf.SetCell(Cell{BOTTOM_LEFT, style}, image.Pt(self.Min.X, self.Max.Y-1))
 	}
 	if self.BorderBottom && self.BorderRight {
-		buf.SetCell(Cell{BOTTOM_RIGHT, self.BorderStyle}, self.Max.Sub(image.Pt(1, 1)))
+		buf.SetCell(Cell{BOTTOM_RIGHT, style}, self.Max.Sub(image.Pt(1, 1)))
 	}
 }
 
@@ -88,6 +98,11 @@ func (self *Block) Draw(buf *Buffer) {
 	)
 }
 
+// SetFocused is called by FocusChain when a widget embedding the Block gains or loses focus.
+func (self *Block) SetFocused(focused bool) {
+	self.Focused = focused
+}
+
 // SetRect implements the Drawable interface.
 func (self *Block) SetRect(x1, y1, x2, y2 int) {
 	self.Rectangle = image.Rect(x1, y1, x2, y2)
@@ -36,34 +36,77 @@ func NewCell(rune rune, args ...interface{}) Cell {
 }
 
 // Buffer represents a section of a terminal and is a renderable rectangle of cells.
+// Cells are stored densely in row-major order. Cells outside of the Buffer's Rectangle are ignored by
+// `SetCell` and `Fill`, and read as an empty Cell by `GetCell`.
 type Buffer struct {
 	image.Rectangle
-	CellMap map[image.Point]Cell
+	cells  []Cell
+	bounds image.Rectangle // area covered by cells, which can be larger than Rectangle for a sub-buffer
 }
 
 func NewBuffer(r image.Rectangle) *Buffer {
+	r = r.Canon()
 	buf := &Buffer{
 		Rectangle: r,
-		CellMap:   make(map[image.Point]Cell),
+		cells:     make([]Cell, r.Dx()*r.Dy()),
+		bounds:    r,
 	}
 	buf.Fill(CellClear, r) // clears out area
 	return buf
 }
 
+func (self *Buffer) index(p image.Point) int {
+	return (p.Y-self.bounds.Min.Y)*self.bounds.Dx() + (p.X - self.bounds.Min.X)
+}
+
 func (self *Buffer) GetCell(p image.Point) Cell {
-	return self.CellMap[p]
+	if !p.In(self.Rectangle) {
+		return Cell{}
+	}
+	return self.cells[self.index(p)]
 }
 
 func (self *Buffer) SetCell(c Cell, p image.Point) {
-	self.CellMap[p] = c
+	if !p.In(self.Rectangle) {
+		return
+	}
+	self.cells[self.index(p)] = c
 }
 
 func (self *Buffer) Fill(c Cell, rect image.Rectangle) {
-	for x := rect.Min.X; x < rect.Max.X; x++ {
-		for y := rect.Min.Y; y < rect.Max.Y; y++ {
-			self.SetCell(c, image.Pt(x, y))
+	rect = rect.Intersect(self.Rectangle)
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		row := self.cells[self.index(image.Pt(rect.Min.X, y)):self.index(image.Pt(rect.Max.X, y))]
+		for x := range row {
+			row[x] = c
+		}
+	}
+}
+
+// Sub returns a view of the Buffer clipped to the given rectangle.
+// The view shares its cells with the Buffer, and coordinates are the same in both.
+func (self *Buffer) Sub(r image.Rectangle) *Buffer {
+	return &Buffer{
+		Rectangle: r.Intersect(self.Rectangle),
+		cells:     self.cells,
+		bounds:    self.bounds,
+	}
+}
+
+// CellMap returns a copy of the Buffer's cells keyed by their position.
+// It replaces the CellMap field of the old map-backed Buffer, but is not compatible with it: every call copies
+// the whole Buffer, and writes to the map do not change the Buffer.
+//
+// Deprecated: Use `GetCell` to read a cell and `SetCell` or `Fill` to write one.
+func (self *Buffer) CellMap() map[image.Point]Cell {
+	cellMap := make(map[image.Point]Cell, self.Dx()*self.Dy())
+	for y := self.Min.Y; y < self.Max.Y; y++ {
+		for x := self.Min.X; x < self.Max.X; x++ {
+			p := image.Pt(x, y)
+			cellMap[p] = self.cells[self.index(p)]
 		}
 	}
+	return cellMap
 }
 
 func (self *Buffer) SetString(s string, style Style, p image.Point) {
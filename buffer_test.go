@@ -0,0 +1,104 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"image"
+	"strings"
+	"testing"
+)
+
+// bufferText returns the runes of a rectangle of a Buffer, one line per row, with '.' for empty cells.
+func bufferText(buf *Buffer, rect image.Rectangle) string {
+	var sb strings.Builder
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		if y > rect.Min.Y {
+			sb.WriteByte('\n')
+		}
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			r := buf.GetCell(image.Pt(x, y)).Rune
+			if r == 0 || r == ' ' {
+				r = '.'
+			}
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+func TestBufferIndex(t *testing.T) {
+	// rectangles are canonicalized
+	buf := NewBuffer(image.Rect(9, 6, 5, 3))
+	if buf.Rectangle != image.Rect(5, 3, 9, 6) {
+		t.Fatalf("buffer is %v", buf.Rectangle)
+	}
+	buf.SetCell(NewCell('a'), image.Pt(5, 3))
+	buf.SetCell(NewCell('b'), image.Pt(8, 3))
+	buf.SetCell(NewCell('c'), image.Pt(5, 4))
+	buf.SetCell(NewCell('d'), image.Pt(8, 5))
+	// outside of the buffer
+	for _, p := range []image.Point{{4, 3}, {9, 3}, {5, 2}, {5, 6}, {0, 0}} {
+		buf.SetCell(NewCell('x'), p)
+		if got := buf.GetCell(p); got != (Cell{}) {
+			t.Errorf("GetCell(%v) outside of the buffer = %v", p, got)
+		}
+	}
+	if got, want := bufferText(buf, buf.Rectangle), "a..b\nc...\n...d"; got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+	if got := buf.CellMap()[image.Pt(8, 5)]; got.Rune != 'd' || len(buf.CellMap()) != 12 {
+		t.Errorf("CellMap() does not hold the cells by position")
+	}
+}
+
+func TestBufferFill(t *testing.T) {
+	buf := NewBuffer(image.Rect(2, 1, 7, 4))
+	buf.Fill(NewCell('#'), image.Rect(0, 0, 4, 3))
+	buf.Fill(NewCell('o'), image.Rect(5, 2, 10, 10))
+	if got, want := bufferText(buf, buf.Rectangle), "##...\n##.oo\n...oo"; got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestBufferSub(t *testing.T) {
+	parent := NewBuffer(image.Rect(10, 5, 16, 9))
+	sub := parent.Sub(image.Rect(12, 6, 20, 8))
+	if sub.Rectangle != image.Rect(12, 6, 16, 8) {
+		t.Fatalf("Sub is %v, want it clipped to its parent", sub.Rectangle)
+	}
+
+	// writes use the coordinates of the parent and are clipped to the view
+	sub.SetString("abcdef", StyleClear, image.Pt(11, 6))
+	sub.SetCell(NewCell('x'), image.Pt(10, 5))
+	sub.SetCell(NewCell('y'), image.Pt(12, 8))
+	sub.Fill(NewCell('#'), image.Rect(0, 7, 14, 20))
+	if got, want := bufferText(parent, parent.Rectangle), "......\n..bcde\n..##..\n......"; got != want {
+		t.Errorf("parent is\n%s\nwant\n%s", got, want)
+	}
+	if got := sub.GetCell(image.Pt(11, 6)); got != (Cell{}) {
+		t.Errorf("GetCell outside of the view = %v", got)
+	}
+
+	// writes to the parent are seen through the view, and through a view of the view
+	parent.SetCell(NewCell('p'), image.Pt(15, 7))
+	inner := sub.Sub(image.Rect(14, 7, 18, 12))
+	if inner.Rectangle != image.Rect(14, 7, 16, 8) {
+		t.Fatalf("inner Sub is %v", inner.Rectangle)
+	}
+	if got := inner.GetCell(image.Pt(15, 7)).Rune; got != 'p' {
+		t.Errorf("view reads %q, want the cell written to its parent", got)
+	}
+	inner.Fill(NewCell('i'), inner.Rectangle)
+	if got, want := bufferText(parent, parent.Rectangle), "......\n..bcde\n..##ii\n......"; got != want {
+		t.Errorf("parent is\n%s\nwant\n%s", got, want)
+	}
+
+	// a view outside of the buffer is empty
+	if empty := parent.Sub(image.Rect(0, 0, 3, 3)); !empty.Empty() {
+		t.Errorf("Sub outside of the buffer is %v", empty.Rectangle)
+	} else {
+		empty.Fill(NewCell('e'), image.Rect(0, 0, 20, 20))
+	}
+}
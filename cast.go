@@ -0,0 +1,196 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	rw "github.com/mattn/go-runewidth"
+)
+
+// CastEnv is the environment variable that makes InitWithBackend record the rendered output to the
+// asciicast file it names, until Close.
+const CastEnv = "TERMUI_CAST"
+
+// castRecorder writes the cells sent to the backend as the output events of an asciicast v2 file.
+type castRecorder struct {
+	w     io.Writer
+	start time.Time
+	size  image.Point
+	out   strings.Builder
+	// the position of the cursor and the style of the terminal after the output so far
+	cursor image.Point
+	style  Style
+	// skip is the second column of the last double width rune, which must not be overwritten
+	skip image.Point
+	err  error
+}
+
+// cast is the current recording, if there is one. It is guarded by the frame lock.
+var (
+	cast     *castRecorder
+	castFile *os.File
+)
+
+// castHeader is the first line of an asciicast v2 file.
+type castHeader struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// StartCastRecording records everything Render draws to an asciicast v2 file, which can be played with
+// asciinema, until StopCastRecording. It must be called after Init. The next Render repaints the whole
+// terminal, so that the recording starts with a complete frame.
+func StartCastRecording(w io.Writer) error {
+	if backend == nil {
+		return errors.New("termui is not initialized")
+	}
+	width, height := backend.Size()
+
+	frame.Lock()
+	defer frame.Unlock()
+	if cast != nil {
+		return errors.New("already recording")
+	}
+	recorder := &castRecorder{
+		w:     w,
+		start: time.Now(),
+		size:  image.Pt(width, height),
+		style: StyleClear,
+		skip:  image.Pt(-1, -1),
+	}
+	header := castHeader{
+		Version:   2,
+		Width:     width,
+		Height:    height,
+		Timestamp: recorder.start.Unix(),
+		Env:       map[string]string{"TERM": os.Getenv("TERM")},
+	}
+	line, err := json.Marshal(header)
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	// hides the cursor, which termui does not use
+	recorder.out.WriteString("\x1b[?25l")
+	cast = recorder
+	frame.stale = true
+	return nil
+}
+
+// StopCastRecording stops the recording started by StartCastRecording, returning the first error that occurred
+// while writing it.
+func StopCastRecording() error {
+	frame.Lock()
+	defer frame.Unlock()
+	if cast == nil {
+		return nil
+	}
+	cast.out.WriteString("\x1b[0m\x1b[?25h")
+	cast.flush()
+	err := cast.err
+	cast = nil
+	return err
+}
+
+// startCastFromEnv starts a recording to the file named by CastEnv, if it is set.
+func startCastFromEnv() {
+	path := os.Getenv(CastEnv)
+	if path == "" || castFile != nil {
+		return
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return
+	}
+	if err := StartCastRecording(f); err != nil {
+		f.Close()
+		return
+	}
+	castFile = f
+}
+
+// stopCastFromEnv stops the recording started by startCastFromEnv.
+func stopCastFromEnv() {
+	if castFile == nil {
+		return
+	}
+	StopCastRecording()
+	castFile.Close()
+	castFile = nil
+}
+
+// writeEvent writes an asciicast event of the given type with the time since the recording started.
+func (self *castRecorder) writeEvent(eventType, data string) {
+	if self.err != nil {
+		return
+	}
+	line, err := json.Marshal([]interface{}{time.Since(self.start).Seconds(), eventType, data})
+	if err == nil {
+		_, err = self.w.Write(append(line, '\n'))
+	}
+	self.err = err
+}
+
+// reset clears the recorded terminal before a repaint, and records a resize if its size changed.
+func (self *castRecorder) reset(size image.Point) {
+	if size != self.size {
+		self.flush()
+		self.writeEvent("r", fmt.Sprintf("%dx%d", size.X, size.Y))
+		self.size = size
+	}
+	self.out.WriteString("\x1b[0m\x1b[2J")
+	self.style = StyleClear
+	self.cursor = image.Pt(-1, -1)
+	self.skip = image.Pt(-1, -1)
+}
+
+// setCell records a cell sent to the backend.
+func (self *castRecorder) setCell(p image.Point, c Cell) {
+	if p == self.skip {
+		return
+	}
+	if p != self.cursor {
+		fmt.Fprintf(&self.out, "\x1b[%d;%dH", p.Y+1, p.X+1)
+	}
+	if c.Style != self.style {
+		self.out.WriteString(ansiSGR(c.Style))
+		self.style = c.Style
+	}
+	if c.Rune == 0 {
+		c.Rune = ' '
+	}
+	self.out.WriteRune(c.Rune)
+	width := rw.RuneWidth(c.Rune)
+	if width < 1 {
+		width = 1
+	}
+	if width == 2 {
+		self.skip = p.Add(image.Pt(1, 0))
+	}
+	self.cursor = p.Add(image.Pt(width, 0))
+}
+
+// flush records the output since the previous flush as one frame.
+func (self *castRecorder) flush() {
+	if self.out.Len() == 0 {
+		return
+	}
+	self.writeEvent("o", self.out.String())
+	self.out.Reset()
+	self.skip = image.Pt(-1, -1)
+}
@@ -0,0 +1,108 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// textDrawable draws a line of text in its top left corner.
+type textDrawable struct {
+	Block
+	text  string
+	style Style
+}
+
+func newTextDrawable(text string, width, height int) *textDrawable {
+	self := &textDrawable{Block: *NewBlock(), text: text, style: StyleClear}
+	self.SetRect(0, 0, width, height)
+	return self
+}
+
+func (self *textDrawable) Draw(buf *Buffer) {
+	buf.SetString(self.text, self.style, self.Min)
+}
+
+// readCast returns the header and the events of an asciicast recording.
+func readCast(t *testing.T, data []byte) (castHeader, [][]interface{}) {
+	t.Helper()
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	var header castHeader
+	if !scanner.Scan() || json.Unmarshal(scanner.Bytes(), &header) != nil {
+		t.Fatalf("invalid header in %q", data)
+	}
+	events := [][]interface{}{}
+	for scanner.Scan() {
+		var event []interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil || len(event) != 3 {
+			t.Fatalf("invalid event %q", scanner.Bytes())
+		}
+		events = append(events, event)
+	}
+	return header, events
+}
+
+func TestCastRecording(t *testing.T) {
+	screen := NewHeadlessBackend(8, 2)
+	if err := InitWithBackend(screen); err != nil {
+		t.Fatal(err)
+	}
+	defer Close()
+	events := PollEvents()
+
+	var buf bytes.Buffer
+	if err := StartCastRecording(&buf); err != nil {
+		t.Fatal(err)
+	}
+	item := newTextDrawable("a世b", 8, 2)
+	Render(item)
+	item.text = "a世c"
+	Render(item)
+	screen.Resize(6, 3)
+	receive(t, events)
+	item.SetRect(0, 0, 6, 3)
+	Render(item)
+	if err := StopCastRecording(); err != nil {
+		t.Fatal(err)
+	}
+
+	header, recorded := readCast(t, buf.Bytes())
+	if header.Version != 2 || header.Width != 8 || header.Height != 2 {
+		t.Errorf("header is %+v, want version 2 for an 8x2 terminal", header)
+	}
+	if len(recorded) != 5 {
+		t.Fatalf("recorded %q, want 5 events", recorded)
+	}
+	for i, e := range recorded {
+		if i > 0 && e[0].(float64) < recorded[i-1][0].(float64) {
+			t.Errorf("event %d is recorded before the previous one", i)
+		}
+	}
+
+	// the first frame repaints the terminal, and the column after the wide rune is not written
+	if e := recorded[0]; e[1] != "o" || !strings.Contains(e[2].(string), "\x1b[2J") ||
+		!strings.Contains(e[2].(string), "\x1b[1;1Ha世b ") {
+		t.Errorf("first frame is %q", e)
+	}
+	// the second frame only has the changed cell
+	if e := recorded[1]; e[1] != "o" || e[2] != "\x1b[1;4Hc" {
+		t.Errorf("second frame is %q, want only the changed cell", e)
+	}
+	if e := recorded[2]; e[1] != "r" || e[2] != "6x3" {
+		t.Errorf("resize is recorded as %q", e)
+	}
+	if e := recorded[3]; e[1] != "o" || !strings.HasPrefix(e[2].(string), "\x1b[0m\x1b[2J") ||
+		!strings.Contains(e[2].(string), "\x1b[3;1H") {
+		t.Errorf("frame after the resize is %q, want a repaint of 3 rows", e)
+	}
+	// stopping shows the cursor again
+	if e := recorded[4]; e[1] != "o" || !strings.HasSuffix(e[2].(string), "\x1b[?25h") {
+		t.Errorf("last event is %q", e)
+	}
+}
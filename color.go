@@ -0,0 +1,174 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ColorDepth is the number of colors a terminal can display.
+type ColorDepth uint
+
+const (
+	// ColorDepthAuto detects the ColorDepth of the terminal with DetectColorDepth.
+	ColorDepthAuto ColorDepth = iota
+	ColorDepthMonochrome
+	// ColorDepth8 only displays colors 0 to 7, without the bright colors of ColorDepth16.
+	ColorDepth8
+	ColorDepth16
+	ColorDepth256
+	ColorDepthTrueColor
+)
+
+// DetectColorDepth guesses the ColorDepth of the terminal from the NO_COLOR, COLORTERM and TERM
+// environment variables and the terminfo database, defaulting to ColorDepth256.
+func DetectColorDepth() ColorDepth {
+	if os.Getenv("NO_COLOR") != "" {
+		return ColorDepthMonochrome
+	}
+	switch os.Getenv("COLORTERM") {
+	case "truecolor", "24bit":
+		return ColorDepthTrueColor
+	}
+
+	term := os.Getenv("TERM")
+	switch {
+	case term == "dumb":
+		return ColorDepthMonochrome
+	case strings.HasSuffix(term, "-direct"):
+		return ColorDepthTrueColor
+	case strings.Contains(term, "256color"):
+		return ColorDepth256
+	}
+
+	colors := terminfoColors(term)
+	switch {
+	case colors >= 1<<24:
+		return ColorDepthTrueColor
+	case colors >= 256:
+		return ColorDepth256
+	case colors >= 16:
+		return ColorDepth16
+	case colors >= 8:
+		return ColorDepth8
+	case colors >= 0:
+		return ColorDepthMonochrome
+	}
+	return ColorDepth256
+}
+
+// Convert returns the color closest to the given Color that can be displayed with the ColorDepth.
+// ColorDepthMonochrome converts every Color to ColorClear.
+func (self ColorDepth) Convert(color Color) Color {
+	if color == ColorClear {
+		return color
+	}
+	switch self {
+	case ColorDepthMonochrome:
+		return ColorClear
+	case ColorDepth8:
+		if color >= 8 && color < 16 {
+			// the bright colors are drawn as their normal counterparts
+			return color - 8
+		}
+		if color.IsRGB() || color >= 16 {
+			return nearestColor(color, 0, 8)
+		}
+	case ColorDepth16:
+		if color.IsRGB() || color >= 16 {
+			return nearestColor(color, 0, 16)
+		}
+	case ColorDepth256:
+		if color.IsRGB() {
+			return nearestColor(color, 16, 256)
+		}
+	}
+	return color
+}
+
+// colorRGB is set on Colors created with NewRGBColor.
+const colorRGB Color = 1 << 24
+
+// NewRGBColor returns a 24-bit Color.
+// On terminals without truecolor support it is drawn as the nearest color of the terminal's ColorDepth.
+func NewRGBColor(r, g, b uint8) Color {
+	return colorRGB | Color(r)<<16 | Color(g)<<8 | Color(b)
+}
+
+// NewHexColor parses a Color of the form `#rrggbb` or `#rgb`. The leading '#' is optional.
+func NewHexColor(hex string) (Color, error) {
+	s := strings.TrimPrefix(hex, "#")
+	if len(s) == 3 {
+		s = string([]byte{s[0], s[0], s[1], s[1], s[2], s[2]})
+	}
+	if len(s) != 6 {
+		return ColorClear, fmt.Errorf("invalid hex color %q", hex)
+	}
+	value, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return ColorClear, fmt.Errorf("invalid hex color %q", hex)
+	}
+	return colorRGB | Color(value), nil
+}
+
+// IsRGB returns whether the Color was created with NewRGBColor or NewHexColor.
+func (self Color) IsRGB() bool {
+	return self > 0 && self&colorRGB != 0
+}
+
+// RGB returns the red, green and blue components of the Color.
+// Xterm colors are converted using the default Xterm palette. ColorClear is returned as black.
+func (self Color) RGB() (uint8, uint8, uint8) {
+	switch {
+	case self.IsRGB():
+		return uint8(self >> 16), uint8(self >> 8), uint8(self)
+	case self >= 0 && self < 256:
+		rgb := xtermPalette[self]
+		return rgb[0], rgb[1], rgb[2]
+	default:
+		return 0, 0, 0
+	}
+}
+
+// xtermLevels are the component values of the 6x6x6 color cube in the Xterm palette.
+var xtermLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+// xtermPalette holds the RGB values of the 256 Xterm colors.
+var xtermPalette = func() (palette [256][3]uint8) {
+	system := [16][3]uint8{
+		{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+		{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+		{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+		{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+	}
+	copy(palette[:], system[:])
+	for i := 0; i < 216; i++ {
+		palette[16+i] = [3]uint8{xtermLevels[i/36], xtermLevels[i/6%6], xtermLevels[i%6]}
+	}
+	for i := 0; i < 24; i++ {
+		gray := uint8(8 + 10*i)
+		palette[232+i] = [3]uint8{gray, gray, gray}
+	}
+	return palette
+}()
+
+// nearestColor returns the color in [min, max) of the Xterm palette closest to the given Color.
+func nearestColor(color Color, min, max int) Color {
+	r, g, b := color.RGB()
+	nearest, nearestDistance := min, -1
+	for i := min; i < max; i++ {
+		dr := int(r) - int(xtermPalette[i][0])
+		dg := int(g) - int(xtermPalette[i][1])
+		db := int(b) - int(xtermPalette[i][2])
+		distance := dr*dr + dg*dg + db*db
+		if nearestDistance == -1 || distance < nearestDistance {
+			nearest, nearestDistance = i, distance
+		}
+	}
+	return Color(nearest)
+}
@@ -0,0 +1,109 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestColorDepthConvert(t *testing.T) {
+	tests := []struct {
+		depth ColorDepth
+		color Color
+		want  Color
+	}{
+		{ColorDepthMonochrome, ColorRed, ColorClear},
+		{ColorDepth8, ColorClear, ColorClear},
+		{ColorDepth8, ColorRed, ColorRed},
+		{ColorDepth8, Color(9), ColorRed},
+		{ColorDepth8, Color(15), ColorWhite},
+		{ColorDepth8, Color(8), ColorBlack},
+		{ColorDepth8, NewRGBColor(0, 0, 250), ColorBlue},
+		{ColorDepth8, Color(46), ColorGreen},
+		{ColorDepth16, Color(9), Color(9)},
+		{ColorDepth16, Color(196), Color(9)},
+		{ColorDepth256, Color(196), Color(196)},
+		{ColorDepth256, NewRGBColor(255, 0, 0), Color(196)},
+		{ColorDepthTrueColor, NewRGBColor(1, 2, 3), NewRGBColor(1, 2, 3)},
+	}
+	for _, test := range tests {
+		if got := test.depth.Convert(test.color); got != test.want {
+			t.Errorf("ColorDepth %d: Convert(%d) = %d, want %d", test.depth, test.color, got, test.want)
+		}
+	}
+}
+
+// writeTerminfo writes a compiled terminfo entry with only the colors capability.
+func writeTerminfo(t *testing.T, dir, term string, colors int) {
+	t.Helper()
+	names := append([]byte(term), 0)
+	header := []int{terminfoMagic, len(names), 0, terminfoColorsIndex + 1, 0, 0}
+	numbers := 12 + len(names) + len(names)%2
+	data := make([]byte, numbers+2*(terminfoColorsIndex+1))
+	for i, n := range header {
+		binary.LittleEndian.PutUint16(data[2*i:], uint16(n))
+	}
+	copy(data[12:], names)
+	for i := 0; i < terminfoColorsIndex; i++ {
+		binary.LittleEndian.PutUint16(data[numbers+2*i:], 0xffff)
+	}
+	binary.LittleEndian.PutUint16(data[numbers+2*terminfoColorsIndex:], uint16(colors))
+
+	if err := os.MkdirAll(filepath.Join(dir, term[:1]), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, term[:1], term), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// setenv sets environment variables until the end of the test.
+func setenv(t *testing.T, env map[string]string) {
+	for key, value := range env {
+		old, ok := os.LookupEnv(key)
+		os.Setenv(key, value)
+		t.Cleanup(func() {
+			if ok {
+				os.Setenv(key, old)
+			} else {
+				os.Unsetenv(key)
+			}
+		})
+	}
+}
+
+func TestDetectColorDepth(t *testing.T) {
+	dir := t.TempDir()
+	writeTerminfo(t, dir, "ti-8", 8)
+	writeTerminfo(t, dir, "ti-16", 16)
+	writeTerminfo(t, dir, "ti-256", 256)
+	setenv(t, map[string]string{"TERMINFO": dir, "TERMINFO_DIRS": "", "HOME": dir})
+
+	tests := []struct {
+		noColor, colorTerm, term string
+		want                     ColorDepth
+	}{
+		{"", "", "ti-8", ColorDepth8},
+		{"", "", "ti-16", ColorDepth16},
+		{"", "", "ti-256", ColorDepth256},
+		{"", "", "unknown-terminal", ColorDepth256},
+		{"", "", "xterm-256color", ColorDepth256},
+		{"", "", "xterm-direct", ColorDepthTrueColor},
+		{"", "", "dumb", ColorDepthMonochrome},
+		{"", "truecolor", "ti-8", ColorDepthTrueColor},
+		{"1", "truecolor", "ti-256", ColorDepthMonochrome},
+	}
+	for _, test := range tests {
+		setenv(t, map[string]string{"NO_COLOR": test.noColor, "COLORTERM": test.colorTerm, "TERM": test.term})
+		if got := DetectColorDepth(); got != test.want {
+			t.Errorf("NO_COLOR=%q COLORTERM=%q TERM=%q: got ColorDepth %d, want %d", test.noColor, test.colorTerm,
+				test.term, got, test.want)
+		}
+	}
+}
@@ -13,6 +13,8 @@ var BRAILLE = [4][2]rune{
 	{'\u0040', '\u0080'},
 }
 
+// Color has the same values as termui.Color, including 24-bit colors.
+// It is declared separately to avoid an import cycle.
 type Color int
 
 type Cell struct {
@@ -5,16 +5,18 @@
 package termui
 
 import (
-	"fmt"
-
-	tb "github.com/nsf/termbox-go"
+	"context"
+	"sync"
 )
 
 /*
 List of events:
 	mouse events:
 		<MouseLeft> <MouseRight> <MouseMiddle>
-		<MouseWheelUp> <MouseWheelDown>
+		<MouseWheelUp> <MouseWheelDown> <MouseRelease>
+		with Gestures:
+		<MouseDragStart> <MouseDragMove> <MouseDragEnd>
+		<MouseDoubleClick> <MouseTripleClick>
 	keyboard events:
 		any uppercase or lowercase letter like j or J
 		<C-d> etc
@@ -23,8 +25,15 @@ List of events:
 		<Insert> <Delete> <Home> <End> <Previous> <Next>
 		<Backspace> <Tab> <Enter> <Escape> <Space>
 		<C-<Space>> etc
+		<S-<Tab>> <C-<Up>> <M-<Up>> <C-<S-<Right>>> etc
+		the payload of keyboard events is a Key with the key code, rune and modifiers
 	terminal events:
         <Resize>
+        <Error>
+        <Paste>
+	custom events:
+		<Interrupt>
+		any ID given to PostEvent
 
     keyboard events that do not work:
         <C-->
@@ -45,6 +54,12 @@ const (
 	KeyboardEvent EventType = iota
 	MouseEvent
 	ResizeEvent
+	// ErrorEvent is sent when the backend fails to read events. Its payload is the error.
+	ErrorEvent
+	// CustomEvent is posted by the program with PostEvent or Interrupt. Its ID and payload are up to the program.
+	CustomEvent
+	// PasteEvent is sent when text is pasted into the terminal. Its payload is the text, as a string.
+	PasteEvent
 )
 
 type Event struct {
@@ -54,10 +69,40 @@ type Event struct {
 }
 
 // Mouse payload.
+// Ctrl, Alt and Shift are the modifier keys held during the mouse action. The termbox backend reports them
+// for terminals sending xterm SGR mouse reports, except on Windows.
 type Mouse struct {
-	Drag bool
-	X    int
-	Y    int
+	Drag  bool
+	X     int
+	Y     int
+	Ctrl  bool
+	Alt   bool
+	Shift bool
+}
+
+// MouseDrag payload of the drag events produced by Gestures.
+// Button is the ID of the button being held, like <MouseLeft>, and StartX and StartY are where it was pressed.
+type MouseDrag struct {
+	Button string
+	StartX int
+	StartY int
+	X      int
+	Y      int
+	Ctrl   bool
+	Alt    bool
+	Shift  bool
+}
+
+// MouseClick payload of the double and triple click events produced by Gestures.
+// Count is the number of clicks, 2 or 3.
+type MouseClick struct {
+	Button string
+	Count  int
+	X      int
+	Y      int
+	Ctrl   bool
+	Alt    bool
+	Shift  bool
 }
 
 // Resize payload.
@@ -66,146 +111,217 @@ type Resize struct {
 	Height int
 }
 
-// PollEvents gets events from termbox, converts them, then sends them to each of its channels.
-func PollEvents() <-chan Event {
-	ch := make(chan Event)
-	go func() {
-		for {
-			ch <- convertTermboxEvent(tb.PollEvent())
-		}
-	}()
-	return ch
-}
-
-var keyboardMap = map[tb.Key]string{
-	tb.KeyF1:         "<F1>",
-	tb.KeyF2:         "<F2>",
-	tb.KeyF3:         "<F3>",
-	tb.KeyF4:         "<F4>",
-	tb.KeyF5:         "<F5>",
-	tb.KeyF6:         "<F6>",
-	tb.KeyF7:         "<F7>",
-	tb.KeyF8:         "<F8>",
-	tb.KeyF9:         "<F9>",
-	tb.KeyF10:        "<F10>",
-	tb.KeyF11:        "<F11>",
-	tb.KeyF12:        "<F12>",
-	tb.KeyInsert:     "<Insert>",
-	tb.KeyDelete:     "<Delete>",
-	tb.KeyHome:       "<Home>",
-	tb.KeyEnd:        "<End>",
-	tb.KeyPgup:       "<PageUp>",
-	tb.KeyPgdn:       "<PageDown>",
-	tb.KeyArrowUp:    "<Up>",
-	tb.KeyArrowDown:  "<Down>",
-	tb.KeyArrowLeft:  "<Left>",
-	tb.KeyArrowRight: "<Right>",
-
-	tb.KeyCtrlSpace:  "<C-<Space>>", // tb.KeyCtrl2 tb.KeyCtrlTilde
-	tb.KeyCtrlA:      "<C-a>",
-	tb.KeyCtrlB:      "<C-b>",
-	tb.KeyCtrlC:      "<C-c>",
-	tb.KeyCtrlD:      "<C-d>",
-	tb.KeyCtrlE:      "<C-e>",
-	tb.KeyCtrlF:      "<C-f>",
-	tb.KeyCtrlG:      "<C-g>",
-	tb.KeyBackspace:  "<C-<Backspace>>", // tb.KeyCtrlH
-	tb.KeyTab:        "<Tab>",           // tb.KeyCtrlI
-	tb.KeyCtrlJ:      "<C-j>",
-	tb.KeyCtrlK:      "<C-k>",
-	tb.KeyCtrlL:      "<C-l>",
-	tb.KeyEnter:      "<Enter>", // tb.KeyCtrlM
-	tb.KeyCtrlN:      "<C-n>",
-	tb.KeyCtrlO:      "<C-o>",
-	tb.KeyCtrlP:      "<C-p>",
-	tb.KeyCtrlQ:      "<C-q>",
-	tb.KeyCtrlR:      "<C-r>",
-	tb.KeyCtrlS:      "<C-s>",
-	tb.KeyCtrlT:      "<C-t>",
-	tb.KeyCtrlU:      "<C-u>",
-	tb.KeyCtrlV:      "<C-v>",
-	tb.KeyCtrlW:      "<C-w>",
-	tb.KeyCtrlX:      "<C-x>",
-	tb.KeyCtrlY:      "<C-y>",
-	tb.KeyCtrlZ:      "<C-z>",
-	tb.KeyEsc:        "<Escape>", // tb.KeyCtrlLsqBracket tb.KeyCtrl3
-	tb.KeyCtrl4:      "<C-4>",    // tb.KeyCtrlBackslash
-	tb.KeyCtrl5:      "<C-5>",    // tb.KeyCtrlRsqBracket
-	tb.KeyCtrl6:      "<C-6>",
-	tb.KeyCtrl7:      "<C-7>", // tb.KeyCtrlSlash tb.KeyCtrlUnderscore
-	tb.KeySpace:      "<Space>",
-	tb.KeyBackspace2: "<Backspace>", // tb.KeyCtrl8:
-}
-
-// convertTermboxKeyboardEvent converts a termbox keyboard event to a more friendly string format.
-// Combines modifiers into the string instead of having them as additional fields in an event.
-func convertTermboxKeyboardEvent(e tb.Event) Event {
-	ID := "%s"
-	if e.Mod == tb.ModAlt {
-		ID = "<M-%s>"
+// eventChannel is a channel returned by PollEvents or PollEventsContext. Each eventChannel has its own queue
+// and goroutine forwarding it, so that a channel that is not read does not hold up the others.
+type eventChannel struct {
+	ch     chan Event
+	ctx    context.Context
+	queue  []Event
+	queued *sync.Cond
+	closed bool
+	sync.Mutex
+}
+
+func newEventChannel(ctx context.Context) *eventChannel {
+	self := &eventChannel{
+		ch:  make(chan Event),
+		ctx: ctx,
 	}
+	self.queued = sync.NewCond(self)
+	return self
+}
 
-	if e.Ch != 0 {
-		ID = fmt.Sprintf(ID, string(e.Ch))
-	} else {
-		converted, ok := keyboardMap[e.Key]
-		if !ok {
-			converted = ""
-		}
-		ID = fmt.Sprintf(ID, converted)
+// push adds events to the queue of the channel without blocking.
+func (self *eventChannel) push(events ...Event) {
+	self.Lock()
+	defer self.Unlock()
+	if !self.closed {
+		self.queue = append(self.queue, events...)
+		self.queued.Signal()
 	}
+}
 
-	return Event{
-		Type: KeyboardEvent,
-		ID:   ID,
+// forward sends the queued events to the channel, in order, until the channel is closed, its context is done
+// or the session ends, and then closes it.
+func (self *eventChannel) forward(done <-chan struct{}) {
+	defer close(self.ch)
+	for {
+		self.Lock()
+		for len(self.queue) == 0 && !self.closed {
+			self.queued.Wait()
+		}
+		if self.closed {
+			self.Unlock()
+			return
+		}
+		e := self.queue[0]
+		self.queue = self.queue[1:]
+		self.Unlock()
+
+		select {
+		case self.ch <- e:
+		case <-self.ctx.Done():
+			self.close()
+			return
+		case <-done:
+			self.close()
+			return
+		}
 	}
 }
 
-var mouseButtonMap = map[tb.Key]string{
-	tb.MouseLeft:      "<MouseLeft>",
-	tb.MouseMiddle:    "<MouseMiddle>",
-	tb.MouseRight:     "<MouseRight>",
-	tb.MouseRelease:   "<MouseRelease>",
-	tb.MouseWheelUp:   "<MouseWheelUp>",
-	tb.MouseWheelDown: "<MouseWheelDown>",
+// close stops the forwarding goroutine, which closes the channel.
+func (self *eventChannel) close() {
+	self.Lock()
+	defer self.Unlock()
+	self.closed = true
+	self.queue = nil
+	self.queued.Broadcast()
 }
 
-func convertTermboxMouseEvent(e tb.Event) Event {
-	converted, ok := mouseButtonMap[e.Key]
-	if !ok {
-		converted = "Unknown_Mouse_Button"
+// poller passes the events read from the backend and those posted with PostEvent to every eventChannel,
+// from the first call to PollEvents after InitWithBackend until Close, which closes done.
+// Events are kept in queue while there are no channels.
+var poller struct {
+	sync.Mutex
+	backend  Backend
+	done     chan struct{}
+	started  bool
+	queue    []Event
+	channels []*eventChannel
+}
+
+// startPoller begins a session of the poller for a backend that was just initialized.
+func startPoller(b Backend) {
+	poller.Lock()
+	defer poller.Unlock()
+	poller.backend = b
+	poller.done = make(chan struct{})
+	poller.started = false
+	poller.queue = nil
+}
+
+// stopPoller ends the session of the poller and closes its channels.
+// The goroutine reading the backend exits when the backend returns from PollEvent after being closed.
+func stopPoller() {
+	poller.Lock()
+	done := poller.done
+	channels := poller.channels
+	poller.done = nil
+	poller.channels = nil
+	poller.queue = nil
+	poller.Unlock()
+
+	if done != nil {
+		close(done)
 	}
-	Drag := e.Mod == tb.ModMotion
-	return Event{
-		Type: MouseEvent,
-		ID:   converted,
-		Payload: Mouse{
-			X:    e.MouseX,
-			Y:    e.MouseY,
-			Drag: Drag,
-		},
+	for _, c := range channels {
+		c.close()
 	}
 }
 
-// convertTermboxEvent turns a termbox event into a termui event.
-func convertTermboxEvent(e tb.Event) Event {
-	if e.Type == tb.EventError {
-		panic(e.Err)
+// queueEvent adds an event to the queue of every channel of the current session, if there is one.
+func queueEvent(e Event) {
+	poller.Lock()
+	defer poller.Unlock()
+	if poller.done == nil {
+		return
 	}
-	switch e.Type {
-	case tb.EventKey:
-		return convertTermboxKeyboardEvent(e)
-	case tb.EventMouse:
-		return convertTermboxMouseEvent(e)
-	case tb.EventResize:
-		return Event{
-			Type: ResizeEvent,
-			ID:   "<Resize>",
-			Payload: Resize{
-				Width:  e.Width,
-				Height: e.Height,
-			},
+	if len(poller.channels) == 0 {
+		poller.queue = append(poller.queue, e)
+	}
+	for _, c := range poller.channels {
+		c.push(e)
+	}
+}
+
+func pollBackend(b Backend, done <-chan struct{}) {
+	for {
+		e := b.PollEvent()
+		select {
+		case <-done:
+			return
+		default:
+		}
+		if e.Type == ResizeEvent {
+			// the backend picks up the new size before the event is sent, so that TerminalDimensions returns it
+			frame.Lock()
+			frame.stale = true
+			clearBackend()
+			frame.Unlock()
+		}
+		if e.ID == "<C-z>" && suspendOnCtrlZ() {
+			Suspend()
+			continue
 		}
+		queueEvent(e)
+	}
+}
+
+// PostEvent adds an event to the stream of PollEvents, after the events already read from the backend.
+// It does not block and can be called from any goroutine, so background workers can use it to wake up the
+// loop reading events, for example with a CustomEvent. Events posted before InitWithBackend or after Close
+// are dropped.
+func PostEvent(e Event) {
+	queueEvent(e)
+}
+
+// Interrupt posts an <Interrupt> CustomEvent, waking up the loop reading events.
+func Interrupt() {
+	PostEvent(Event{
+		Type: CustomEvent,
+		ID:   "<Interrupt>",
+	})
+}
+
+// PollEvents gets events from the backend, then sends them to each of its channels.
+// Every channel has its own queue, so events wait there until it is read, without holding up the others.
+// The channels are closed by Close.
+func PollEvents() <-chan Event {
+	return PollEventsContext(context.Background())
+}
+
+// PollEventsContext is like PollEvents, but the channel is also closed when ctx is done.
+func PollEventsContext(ctx context.Context) <-chan Event {
+	c := newEventChannel(ctx)
+
+	poller.Lock()
+	done := poller.done
+	if done == nil {
+		// termui is not initialized
+		poller.Unlock()
+		close(c.ch)
+		return c.ch
+	}
+	poller.channels = append(poller.channels, c)
+	if len(poller.queue) > 0 {
+		c.push(poller.queue...)
+		poller.queue = nil
+	}
+	go c.forward(done)
+	if !poller.started {
+		poller.started = true
+		go pollBackend(poller.backend, done)
+	}
+	poller.Unlock()
+
+	if ctx.Done() != nil {
+		go func() {
+			select {
+			case <-ctx.Done():
+			case <-done:
+				return
+			}
+			poller.Lock()
+			for i, channel := range poller.channels {
+				if channel == c {
+					poller.channels = append(poller.channels[:i], poller.channels[i+1:]...)
+					break
+				}
+			}
+			poller.Unlock()
+			c.close()
+		}()
 	}
-	return Event{}
+	return c.ch
 }
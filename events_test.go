@@ -0,0 +1,81 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func receive(t *testing.T, events <-chan Event) Event {
+	t.Helper()
+	select {
+	case e := <-events:
+		return e
+	case <-time.After(time.Second):
+		t.Fatal("no event received")
+	}
+	return Event{}
+}
+
+func TestPollEventsUnreadChannel(t *testing.T) {
+	screen := NewHeadlessBackend(10, 5)
+	if err := InitWithBackend(screen); err != nil {
+		t.Fatal(err)
+	}
+	defer Close()
+
+	PollEvents() // never read
+	events := PollEvents()
+	for i := 0; i < 3; i++ {
+		screen.PostEvent(Event{Type: KeyboardEvent, ID: fmt.Sprint(i)})
+	}
+	for i := 0; i < 3; i++ {
+		if e := receive(t, events); e.ID != fmt.Sprint(i) {
+			t.Errorf("got event %q, want %q", e.ID, fmt.Sprint(i))
+		}
+	}
+}
+
+func TestPollEventsBroadcast(t *testing.T) {
+	screen := NewHeadlessBackend(10, 5)
+	if err := InitWithBackend(screen); err != nil {
+		t.Fatal(err)
+	}
+	defer Close()
+
+	first, second := PollEvents(), PollEvents()
+	PostEvent(Event{Type: CustomEvent, ID: "posted"})
+	for _, events := range []<-chan Event{first, second} {
+		if e := receive(t, events); e.ID != "posted" {
+			t.Errorf("got event %q, want %q", e.ID, "posted")
+		}
+	}
+}
+
+func TestPollEventsClose(t *testing.T) {
+	screen := NewHeadlessBackend(10, 5)
+	if err := InitWithBackend(screen); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := PollEventsContext(ctx)
+	events := PollEvents()
+	cancel()
+	if _, ok := <-cancelled; ok {
+		t.Error("channel not closed when its context is done")
+	}
+
+	Close()
+	if _, ok := <-events; ok {
+		t.Error("channel not closed by Close")
+	}
+	if _, ok := <-PollEvents(); ok {
+		t.Error("channel of PollEvents not closed after Close")
+	}
+}
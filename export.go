@@ -0,0 +1,284 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"fmt"
+	"html"
+	"image"
+	"strconv"
+	"strings"
+
+	rw "github.com/mattn/go-runewidth"
+)
+
+// Colors used in place of ColorClear by EncodeHTML and EncodeSVG.
+var (
+	ExportDefaultFg = ColorWhite
+	ExportDefaultBg = ColorBlack
+)
+
+const (
+	svgFontSize   = 14
+	svgCellWidth  = 8.4
+	svgCellHeight = 17
+)
+
+// exportRun is a horizontal run of cells with the same Style and rune width.
+type exportRun struct {
+	X     int
+	Width int
+	Text  string
+	Style Style
+}
+
+// exportRows splits each row of the Buffer into runs.
+// The cell following a double width rune is skipped, since the rune covers it.
+func exportRows(buf *Buffer) [][]exportRun {
+	rows := [][]exportRun{}
+	for y := buf.Min.Y; y < buf.Max.Y; y++ {
+		runs := []exportRun{}
+		var sb strings.Builder
+		run := exportRun{}
+		wide := false
+		for x := buf.Min.X; x < buf.Max.X; {
+			cell := buf.GetCell(image.Pt(x, y))
+			if cell.Rune == 0 {
+				cell.Rune = ' '
+			}
+			width := rw.RuneWidth(cell.Rune)
+			if width < 1 {
+				width = 1
+			}
+			if run.Width > 0 && (cell.Style != run.Style || (width == 2) != wide) {
+				run.Text = sb.String()
+				runs = append(runs, run)
+				sb.Reset()
+				run = exportRun{}
+			}
+			if run.Width == 0 {
+				run.X = x - buf.Min.X
+				run.Style = cell.Style
+				wide = width == 2
+			}
+			sb.WriteRune(cell.Rune)
+			run.Width += width
+			x += width
+		}
+		if run.Width > 0 {
+			run.Text = sb.String()
+			runs = append(runs, run)
+		}
+		rows = append(rows, runs)
+	}
+	return rows
+}
+
+// ansiModifierCodes are the SGR codes of each Modifier, in the order they are emitted.
+var ansiModifierCodes = []struct {
+	Modifier Modifier
+	Code     int
+}{
+	{ModifierBold, 1},
+	{ModifierDim, 2},
+	{ModifierItalic, 3},
+	{ModifierUnderline, 4},
+	{ModifierBlink, 5},
+	{ModifierReverse, 7},
+	{ModifierHidden, 8},
+	{ModifierStrikethrough, 9},
+	{ModifierDoubleUnderline, 21},
+}
+
+// ansiColorCodes returns the SGR codes selecting a foreground or background color.
+func ansiColorCodes(color Color, background bool) []string {
+	base := 30
+	if background {
+		base = 40
+	}
+	switch {
+	case color.IsRGB():
+		r, g, b := color.RGB()
+		return []string{
+			strconv.Itoa(base + 8), "2",
+			strconv.Itoa(int(r)), strconv.Itoa(int(g)), strconv.Itoa(int(b)),
+		}
+	case color >= 0 && color < 8:
+		return []string{strconv.Itoa(base + int(color))}
+	case color >= 8 && color < 16:
+		return []string{strconv.Itoa(base + 60 + int(color) - 8)}
+	case color >= 16 && color < 256:
+		return []string{strconv.Itoa(base + 8), "5", strconv.Itoa(int(color))}
+	}
+	return nil
+}
+
+// ansiSGR returns the escape sequence resetting the terminal to the given Style.
+func ansiSGR(style Style) string {
+	codes := []string{"0"}
+	for _, m := range ansiModifierCodes {
+		if style.Modifier&m.Modifier != 0 {
+			codes = append(codes, strconv.Itoa(m.Code))
+		}
+	}
+	codes = append(codes, ansiColorCodes(style.Fg, false)...)
+	codes = append(codes, ansiColorCodes(style.Bg, true)...)
+	return "\x1b[" + strings.Join(codes, ";") + "m"
+}
+
+// EncodeANSI returns the Buffer as lines of text with ANSI escape sequences for its styles.
+// The result can be printed to a terminal or parsed back with ParseANSI.
+func EncodeANSI(buf *Buffer) string {
+	var sb strings.Builder
+	for y, runs := range exportRows(buf) {
+		if y > 0 {
+			sb.WriteByte('\n')
+		}
+		style := StyleClear
+		for _, run := range runs {
+			if run.Style != style {
+				sb.WriteString(ansiSGR(run.Style))
+				style = run.Style
+			}
+			sb.WriteString(run.Text)
+		}
+		if style != StyleClear {
+			sb.WriteString("\x1b[0m")
+		}
+	}
+	return sb.String()
+}
+
+// cssColor formats a Color as a CSS hex color, using the given Color in place of ColorClear.
+func cssColor(color, clear Color) string {
+	if color == ColorClear {
+		color = clear
+	}
+	r, g, b := color.RGB()
+	return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+}
+
+// exportColors returns the foreground and background colors of a Style as CSS colors, applying
+// ModifierReverse and ModifierHidden.
+func exportColors(style Style) (string, string) {
+	fg := cssColor(style.Fg, ExportDefaultFg)
+	bg := cssColor(style.Bg, ExportDefaultBg)
+	if style.Modifier&ModifierReverse != 0 {
+		fg, bg = bg, fg
+	}
+	if style.Modifier&ModifierHidden != 0 {
+		fg = bg
+	}
+	return fg, bg
+}
+
+// exportTextDecoration returns the CSS text-decoration of a Style.
+func exportTextDecoration(style Style) string {
+	decorations := []string{}
+	if style.Modifier&(ModifierUnderline|ModifierDoubleUnderline) != 0 {
+		decorations = append(decorations, "underline")
+	}
+	if style.Modifier&ModifierStrikethrough != 0 {
+		decorations = append(decorations, "line-through")
+	}
+	if style.Modifier&ModifierDoubleUnderline != 0 {
+		decorations = append(decorations, "double")
+	}
+	return strings.Join(decorations, " ")
+}
+
+// EncodeHTML returns the Buffer as a standalone HTML <pre> element with inline styles.
+func EncodeHTML(buf *Buffer) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(
+		`<pre style="font-family: monospace; line-height: 1.2; color: %s; background-color: %s; display: inline-block; margin: 0">`,
+		cssColor(ColorClear, ExportDefaultFg), cssColor(ColorClear, ExportDefaultBg),
+	))
+	for y, runs := range exportRows(buf) {
+		if y > 0 {
+			sb.WriteByte('\n')
+		}
+		for _, run := range runs {
+			text := html.EscapeString(run.Text)
+			if run.Style == StyleClear {
+				sb.WriteString(text)
+				continue
+			}
+			fg, bg := exportColors(run.Style)
+			css := []string{"color: " + fg, "background-color: " + bg}
+			if run.Style.Modifier&ModifierBold != 0 {
+				css = append(css, "font-weight: bold")
+			}
+			if run.Style.Modifier&ModifierItalic != 0 {
+				css = append(css, "font-style: italic")
+			}
+			if run.Style.Modifier&ModifierDim != 0 {
+				css = append(css, "opacity: 0.5")
+			}
+			if decoration := exportTextDecoration(run.Style); decoration != "" {
+				css = append(css, "text-decoration: "+decoration)
+			}
+			sb.WriteString(fmt.Sprintf(`<span style="%s">%s</span>`, strings.Join(css, "; "), text))
+		}
+	}
+	sb.WriteString("</pre>\n")
+	return sb.String()
+}
+
+// EncodeSVG returns the Buffer as a standalone SVG image with monospaced text.
+func EncodeSVG(buf *Buffer) string {
+	width := float64(buf.Dx()) * svgCellWidth
+	height := buf.Dy() * svgCellHeight
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(
+		`<svg xmlns="http://www.w3.org/2000/svg" width="%.1f" height="%d" viewBox="0 0 %.1f %d" font-family="monospace" font-size="%d">`+"\n",
+		width, height, width, height, svgFontSize,
+	))
+	sb.WriteString(fmt.Sprintf(`<rect width="100%%" height="100%%" fill="%s"/>`+"\n", cssColor(ColorClear, ExportDefaultBg)))
+
+	rows := exportRows(buf)
+	// backgrounds are drawn first, so that they do not cover the text of wide runes
+	for y, runs := range rows {
+		for _, run := range runs {
+			_, bg := exportColors(run.Style)
+			if bg == cssColor(ColorClear, ExportDefaultBg) {
+				continue
+			}
+			sb.WriteString(fmt.Sprintf(
+				`<rect x="%.1f" y="%d" width="%.1f" height="%d" fill="%s"/>`+"\n",
+				float64(run.X)*svgCellWidth, y*svgCellHeight, float64(run.Width)*svgCellWidth, svgCellHeight, bg,
+			))
+		}
+	}
+	for y, runs := range rows {
+		for _, run := range runs {
+			if strings.TrimSpace(run.Text) == "" {
+				continue
+			}
+			fg, _ := exportColors(run.Style)
+			attrs := fmt.Sprintf(`fill="%s"`, fg)
+			if run.Style.Modifier&ModifierBold != 0 {
+				attrs += ` font-weight="bold"`
+			}
+			if run.Style.Modifier&ModifierItalic != 0 {
+				attrs += ` font-style="italic"`
+			}
+			if run.Style.Modifier&ModifierDim != 0 {
+				attrs += ` opacity="0.5"`
+			}
+			if decoration := exportTextDecoration(run.Style); decoration != "" {
+				attrs += fmt.Sprintf(` text-decoration="%s"`, decoration)
+			}
+			sb.WriteString(fmt.Sprintf(
+				`<text x="%.1f" y="%d" textLength="%.1f" lengthAdjust="spacingAndGlyphs" xml:space="preserve" %s>%s</text>`+"\n",
+				float64(run.X)*svgCellWidth, y*svgCellHeight+svgFontSize, float64(run.Width)*svgCellWidth,
+				attrs, html.EscapeString(run.Text),
+			))
+		}
+	}
+	sb.WriteString("</svg>\n")
+	return sb.String()
+}
@@ -0,0 +1,172 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"image"
+	"reflect"
+	"strings"
+	"testing"
+
+	rw "github.com/mattn/go-runewidth"
+)
+
+// exportBuffer returns a Buffer of one row with the text of each span in its style.
+func exportBuffer(width int, spans ...span) *Buffer {
+	buf := NewBuffer(image.Rect(0, 0, width, 1))
+	x := 0
+	for _, s := range spans {
+		buf.SetString(s.Text, s.Style, image.Pt(x, 0))
+		for _, r := range s.Text {
+			x += rw.RuneWidth(r)
+		}
+	}
+	return buf
+}
+
+// visibleCells returns the cells of a Buffer as ParseANSI returns them from EncodeANSI, without the cells
+// covered by wide runes.
+func visibleCells(buf *Buffer) []Cell {
+	cells := []Cell{}
+	for y := buf.Min.Y; y < buf.Max.Y; y++ {
+		if y > buf.Min.Y {
+			cells = append(cells, Cell{'\n', StyleClear})
+		}
+		for x := buf.Min.X; x < buf.Max.X; {
+			cell := buf.GetCell(image.Pt(x, y))
+			cells = append(cells, cell)
+			x += rw.RuneWidth(cell.Rune)
+		}
+	}
+	return cells
+}
+
+func TestEncodeANSIRoundTrip(t *testing.T) {
+	allModifiers := ModifierBold | ModifierDim | ModifierItalic | ModifierUnderline | ModifierBlink |
+		ModifierReverse | ModifierHidden | ModifierStrikethrough | ModifierDoubleUnderline
+	tests := []struct {
+		name string
+		buf  *Buffer
+	}{
+		{"plain", exportBuffer(5, span{"plain", StyleClear})},
+		{"basic colors", exportBuffer(6, span{"red", NewStyle(ColorRed)}, span{"on", NewStyle(ColorClear, ColorBlue)})},
+		{"bright colors", exportBuffer(4, span{"hi", NewStyle(Color(9), Color(15))})},
+		{"xterm colors", exportBuffer(4, span{"208", NewStyle(Color(208), Color(17))})},
+		{"rgb colors", exportBuffer(3, span{"rgb", NewStyle(NewRGBColor(1, 128, 255), NewRGBColor(250, 0, 3))})},
+		{"modifiers", exportBuffer(3, span{"all", NewStyle(ColorGreen, ColorClear, allModifiers)})},
+		{"wide runes", exportBuffer(8, span{"a", StyleClear}, span{"世界", NewStyle(ColorCyan)}, span{"b", NewStyle(ColorCyan)})},
+		{"rows", func() *Buffer {
+			buf := NewBuffer(image.Rect(2, 3, 6, 5))
+			buf.SetString("top", NewStyle(ColorYellow, ColorClear, ModifierBold), image.Pt(2, 3))
+			buf.SetString("世", NewStyle(NewRGBColor(9, 9, 9)), image.Pt(4, 4))
+			return buf
+		}()},
+	}
+	for _, test := range tests {
+		encoded := EncodeANSI(test.buf)
+		got := ParseANSI(encoded, StyleClear)
+		if want := visibleCells(test.buf); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: %q is parsed as %v, want %v", test.name, encoded, spans(got), spans(want))
+		}
+	}
+}
+
+func TestExportRuns(t *testing.T) {
+	red := NewStyle(ColorRed)
+	buf := exportBuffer(10, span{"ab", red}, span{"世界", red}, span{"c", red}, span{"d", StyleClear})
+	want := []exportRun{
+		{X: 0, Width: 2, Text: "ab", Style: red},
+		{X: 2, Width: 4, Text: "世界", Style: red},
+		{X: 6, Width: 1, Text: "c", Style: red},
+		{X: 7, Width: 3, Text: "d  ", Style: StyleClear},
+	}
+	if got := exportRows(buf); !reflect.DeepEqual(got, [][]exportRun{want}) {
+		t.Errorf("got runs %+v, want %+v", got, want)
+	}
+}
+
+func TestEncodeHTML(t *testing.T) {
+	red, blue := cssColor(ColorRed, ColorClear), cssColor(ColorBlue, ColorClear)
+	tests := []struct {
+		name  string
+		buf   *Buffer
+		wants []string
+	}{
+		{"escaped", exportBuffer(8, span{`<b>&"x"`, StyleClear}), []string{`&lt;b&gt;&amp;&#34;x&#34;`}},
+		{"colors", exportBuffer(1, span{"x", NewStyle(ColorRed, ColorBlue)}), []string{
+			`<span style="color: ` + red + `; background-color: ` + blue + `">x</span>`,
+		}},
+		{"reverse", exportBuffer(1, span{"x", NewStyle(ColorRed, ColorBlue, ModifierReverse)}), []string{
+			`color: ` + blue + `; background-color: ` + red,
+		}},
+		{"hidden", exportBuffer(1, span{"x", NewStyle(ColorRed, ColorBlue, ModifierHidden)}), []string{
+			`color: ` + blue + `; background-color: ` + blue,
+		}},
+		{"double underline", exportBuffer(1, span{"x", NewStyle(ColorRed, ColorClear, ModifierDoubleUnderline|ModifierStrikethrough)}),
+			[]string{`text-decoration: underline line-through double`}},
+		{"text styles", exportBuffer(1, span{"x", NewStyle(ColorRed, ColorClear, ModifierBold|ModifierItalic|ModifierDim)}),
+			[]string{`font-weight: bold; font-style: italic; opacity: 0.5`}},
+		{"wide runes", exportBuffer(4, span{"a世b", NewStyle(ColorRed)}), []string{`>a</span><span`, `>世</span><span`, `>b</span>`}},
+	}
+	for _, test := range tests {
+		encoded := EncodeHTML(test.buf)
+		if !strings.HasPrefix(encoded, "<pre ") || !strings.HasSuffix(encoded, "</pre>\n") {
+			t.Errorf("%s: %q is not a <pre> element", test.name, encoded)
+		}
+		for _, want := range test.wants {
+			if !strings.Contains(encoded, want) {
+				t.Errorf("%s: %q does not contain %q", test.name, encoded, want)
+			}
+		}
+	}
+}
+
+func TestEncodeSVG(t *testing.T) {
+	buf := exportBuffer(6, span{"<a", StyleClear}, span{"世", NewStyle(ColorRed, ColorBlue, ModifierReverse)}, span{"&", StyleClear})
+	encoded := EncodeSVG(buf)
+	wants := []string{
+		`width="50.4" height="17"`,
+		`<text x="0.0" y="14" textLength="16.8" lengthAdjust="spacingAndGlyphs" xml:space="preserve" fill="` +
+			cssColor(ColorClear, ExportDefaultFg) + `">&lt;a</text>`,
+		`<rect x="16.8" y="0" width="16.8" height="17" fill="` + cssColor(ColorRed, ColorClear) + `"/>`,
+		`textLength="16.8" lengthAdjust="spacingAndGlyphs" xml:space="preserve" fill="` +
+			cssColor(ColorBlue, ColorClear) + `">世</text>`,
+		`<text x="33.6" y="14" textLength="16.8"`,
+		`>&amp; </text>`,
+	}
+	for _, want := range wants {
+		if !strings.Contains(encoded, want) {
+			t.Errorf("%q does not contain %q", encoded, want)
+		}
+	}
+}
+
+func TestFrame(t *testing.T) {
+	screen := NewHeadlessBackend(6, 2)
+	if err := InitWithBackend(screen); err != nil {
+		t.Fatal(err)
+	}
+	defer Close()
+
+	block := NewBlock()
+	block.SetRect(0, 0, 6, 2)
+	block.Title = "世"
+	Render(block)
+
+	frame := Frame()
+	if frame.Rectangle != image.Rect(0, 0, 6, 2) {
+		t.Fatalf("Frame is %v, want the size of the screen", frame.Rectangle)
+	}
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 6; x++ {
+			if got, want := frame.GetCell(image.Pt(x, y)), screen.Cell(x, y); got != want {
+				t.Errorf("Frame cell (%d,%d) is %v, want the flushed %v", x, y, got, want)
+			}
+		}
+	}
+	if got := EncodeANSI(frame); !strings.Contains(got, "世") {
+		t.Errorf("EncodeANSI(Frame()) = %q, want the title", got)
+	}
+}
@@ -0,0 +1,326 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"image"
+	"math"
+)
+
+// FlexDirection is the direction the items of a Flex are laid out in.
+type FlexDirection uint
+
+const (
+	// FlexRow places items side by side, from left to right.
+	FlexRow FlexDirection = iota
+	// FlexColumn stacks items from top to bottom.
+	FlexColumn
+)
+
+// FlexJustify is how the space left on a line of a Flex is divided around its items.
+type FlexJustify uint
+
+const (
+	JustifyStart FlexJustify = iota
+	JustifyEnd
+	JustifyCenter
+	JustifySpaceBetween
+	JustifySpaceAround
+	JustifySpaceEvenly
+)
+
+// FlexAlign is how items are placed across a line of a Flex.
+type FlexAlign uint
+
+const (
+	// AlignItemsStretch makes every item as large as the line.
+	AlignItemsStretch FlexAlign = iota
+	AlignItemsStart
+	AlignItemsEnd
+	AlignItemsCenter
+)
+
+// FlexItem is a Drawable in a Flex and how it is sized. Sizes are in cells along the direction of the Flex,
+// and CrossSize across it.
+type FlexItem struct {
+	Drawable
+	// Basis is the size of the item before the free space of its line is distributed.
+	Basis int
+	// Grow is the share of the free space of its line the item takes, relative to the other items.
+	Grow float64
+	// Shrink is how much the item gives up when its line overflows, relative to the other items and
+	// weighted by Basis.
+	Shrink float64
+	// MinSize and MaxSize limit the size of the item after growing or shrinking. A MaxSize of 0 means
+	// no limit.
+	MinSize int
+	MaxSize int
+	// CrossSize is the size of the item across the line, unless the Flex stretches its items.
+	// An item with a CrossSize of 0 is as large as its line.
+	CrossSize int
+}
+
+// Flex is a Drawable that lays out other Drawables like a CSS flexbox: along a line, sized from their basis
+// by growing into the free space or shrinking when they overflow, then justified along the line and aligned
+// across it. With Wrap, items that do not fit start a new line. The Padding and the border of the Block are
+// kept around the items.
+//
+// Items are laid out every time the Flex is drawn, so giving it the new size of the terminal on <Resize>
+// is enough to lay them out again. Call Layout to position them without drawing.
+type Flex struct {
+	Block
+	Direction FlexDirection
+	Justify   FlexJustify
+	Align     FlexAlign
+	Wrap      bool
+	// Gap is the number of cells between items and between lines.
+	Gap   int
+	Items []*FlexItem
+}
+
+// NewFlex returns a Flex without a border. Like Grid, it should usually be given the size of the terminal.
+func NewFlex(direction FlexDirection) *Flex {
+	self := &Flex{
+		Block:     *NewBlock(),
+		Direction: direction,
+	}
+	self.Border = false
+	return self
+}
+
+// Add appends items to the Flex.
+func (self *Flex) Add(items ...*FlexItem) {
+	self.Lock()
+	defer self.Unlock()
+	self.Items = append(self.Items, items...)
+}
+
+// Children implements the Container interface.
+func (self *Flex) Children() []Drawable {
+	self.Lock()
+	defer self.Unlock()
+	children := make([]Drawable, len(self.Items))
+	for i, item := range self.Items {
+		children[i] = item.Drawable
+	}
+	return children
+}
+
+// area returns the rectangle the items are laid out in.
+func (self *Flex) area() image.Rectangle {
+	if self.Border {
+		return self.Inner
+	}
+	return image.Rect(
+		self.Min.X+self.PaddingLeft,
+		self.Min.Y+self.PaddingTop,
+		self.Max.X-self.PaddingRight,
+		self.Max.Y-self.PaddingBottom,
+	)
+}
+
+// lines splits the items into lines that fit in a size, or returns them as one line without Wrap.
+func (self *Flex) lines(size int) [][]*FlexItem {
+	if !self.Wrap {
+		return [][]*FlexItem{self.Items}
+	}
+	var lines [][]*FlexItem
+	var line []*FlexItem
+	used := 0
+	for _, item := range self.Items {
+		if len(line) > 0 && used+self.Gap+item.Basis > size {
+			lines = append(lines, line)
+			line = nil
+		}
+		if len(line) > 0 {
+			used += self.Gap
+		} else {
+			used = 0
+		}
+		line = append(line, item)
+		used += item.Basis
+	}
+	if len(line) > 0 {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func (self *FlexItem) clamp(size float64) float64 {
+	if self.MaxSize > 0 && size > float64(self.MaxSize) {
+		size = float64(self.MaxSize)
+	}
+	return math.Max(size, float64(self.MinSize))
+}
+
+// flexSizes returns the sizes of the items of a line of a given size, grown or shrunk from their basis.
+// Items that reach a limit are held at it, and the others share the rest.
+func flexSizes(line []*FlexItem, size, gap int) []float64 {
+	sizes := make([]float64, len(line))
+	free := float64(size - gap*(len(line)-1))
+	flexible := make([]int, 0, len(line))
+	for i, item := range line {
+		sizes[i] = item.clamp(float64(item.Basis))
+		if sizes[i] == float64(item.Basis) {
+			flexible = append(flexible, i)
+		}
+		free -= sizes[i]
+	}
+
+	for len(flexible) > 0 && free != 0 {
+		weights := 0.0
+		for _, i := range flexible {
+			if free > 0 {
+				weights += math.Max(line[i].Grow, 0)
+			} else {
+				weights += math.Max(line[i].Shrink, 0) * float64(line[i].Basis)
+			}
+		}
+		if weights == 0 {
+			break
+		}
+
+		var unlimited []int
+		distributed := 0.0
+		for _, i := range flexible {
+			weight := math.Max(line[i].Shrink, 0) * float64(line[i].Basis)
+			if free > 0 {
+				weight = math.Max(line[i].Grow, 0)
+			}
+			want := math.Max(sizes[i]+free*weight/weights, 0)
+			got := line[i].clamp(want)
+			distributed += got - sizes[i]
+			sizes[i] = got
+			if got == want && weight > 0 {
+				unlimited = append(unlimited, i)
+			}
+		}
+		free -= distributed
+		if len(unlimited) == len(flexible) {
+			break
+		}
+		flexible = unlimited
+	}
+	return sizes
+}
+
+// justify returns the offset of the first item of a line and the extra space between its items.
+func (self *Flex) justify(free float64, count int) (float64, float64) {
+	if free <= 0 {
+		return 0, 0
+	}
+	switch self.Justify {
+	case JustifyEnd:
+		return free, 0
+	case JustifyCenter:
+		return free / 2, 0
+	case JustifySpaceBetween:
+		if count > 1 {
+			return 0, free / float64(count-1)
+		}
+	case JustifySpaceAround:
+		return free / float64(count) / 2, free / float64(count)
+	case JustifySpaceEvenly:
+		return free / float64(count+1), free / float64(count+1)
+	}
+	return 0, 0
+}
+
+// align returns the offset and size of an item across a line.
+func (self *Flex) align(item *FlexItem, lineSize int) (int, int) {
+	if self.Align == AlignItemsStretch || item.CrossSize <= 0 || item.CrossSize >= lineSize {
+		return 0, lineSize
+	}
+	switch self.Align {
+	case AlignItemsEnd:
+		return lineSize - item.CrossSize, item.CrossSize
+	case AlignItemsCenter:
+		return (lineSize - item.CrossSize) / 2, item.CrossSize
+	}
+	return 0, item.CrossSize
+}
+
+// Layout sets the rectangles of the items from the rectangle of the Flex, like Draw does.
+func (self *Flex) Layout() {
+	self.Lock()
+	defer self.Unlock()
+	self.layout()
+}
+
+func (self *Flex) layout() {
+	area := self.area()
+	if area.Empty() {
+		for _, item := range self.Items {
+			item.SetRect(0, 0, 0, 0)
+		}
+		return
+	}
+	mainSize, crossSize := area.Dx(), area.Dy()
+	if self.Direction == FlexColumn {
+		mainSize, crossSize = crossSize, mainSize
+	}
+	lines := self.lines(mainSize)
+
+	// lines are as large as their largest CrossSize, and share the rest of the area equally
+	lineSizes := make([]float64, len(lines))
+	free := float64(crossSize - self.Gap*(len(lines)-1))
+	for i, line := range lines {
+		for _, item := range line {
+			if self.Align != AlignItemsStretch && float64(item.CrossSize) > lineSizes[i] {
+				lineSizes[i] = float64(item.CrossSize)
+			}
+		}
+		free -= lineSizes[i]
+	}
+	if free > 0 {
+		for i := range lineSizes {
+			lineSizes[i] += free / float64(len(lines))
+		}
+	}
+
+	crossPos := 0.0
+	for i, line := range lines {
+		crossStart := int(math.Round(crossPos))
+		crossPos += lineSizes[i]
+		lineSize := int(math.Round(crossPos)) - crossStart
+		crossPos += float64(self.Gap)
+
+		sizes := flexSizes(line, mainSize, self.Gap)
+		used := float64(self.Gap * (len(line) - 1))
+		for _, size := range sizes {
+			used += size
+		}
+		mainPos, spacing := self.justify(float64(mainSize)-used, len(line))
+
+		for j, item := range line {
+			mainStart := int(math.Round(mainPos))
+			mainPos += sizes[j]
+			mainEnd := int(math.Round(mainPos))
+			mainPos += float64(self.Gap) + spacing
+
+			offset, size := self.align(item, lineSize)
+			rect := image.Rect(mainStart, crossStart+offset, mainEnd, crossStart+offset+size)
+			if self.Direction == FlexColumn {
+				rect = image.Rect(rect.Min.Y, rect.Min.X, rect.Max.Y, rect.Max.X)
+			}
+			rect = rect.Add(area.Min).Intersect(area)
+			item.SetRect(rect.Min.X, rect.Min.Y, rect.Max.X, rect.Max.Y)
+		}
+	}
+}
+
+// Draw implements the Drawable interface.
+func (self *Flex) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+	self.layout()
+	for _, item := range self.Items {
+		if item.GetRect().Empty() {
+			continue
+		}
+		item.Lock()
+		item.Draw(buf.Sub(item.GetRect()))
+		item.Unlock()
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"image"
+	"reflect"
+	"testing"
+)
+
+func TestFlexSizes(t *testing.T) {
+	tests := []struct {
+		name string
+		line []*FlexItem
+		size int
+		gap  int
+		want []float64
+	}{
+		{"basis", []*FlexItem{{Basis: 3}, {Basis: 4}}, 10, 0, []float64{3, 4}},
+		{"grow", []*FlexItem{{Basis: 4, Grow: 1}, {Basis: 4, Grow: 3}}, 20, 0, []float64{7, 13}},
+		{"grow with a gap", []*FlexItem{{Grow: 1}, {Grow: 1}}, 10, 2, []float64{4, 4}},
+		{"grow to max", []*FlexItem{{Basis: 2, Grow: 1, MaxSize: 5}, {Basis: 2, Grow: 1}}, 20, 0, []float64{5, 15}},
+		{"basis over max", []*FlexItem{{Basis: 8, Grow: 1, MaxSize: 5}, {Grow: 1}}, 20, 0, []float64{5, 15}},
+		{"shrink by basis", []*FlexItem{{Basis: 8, Shrink: 1}, {Basis: 4, Shrink: 1}}, 9, 0, []float64{6, 3}},
+		{"shrink to min", []*FlexItem{{Basis: 8, Shrink: 1, MinSize: 7}, {Basis: 8, Shrink: 1}}, 10, 0, []float64{7, 3}},
+		{"no shrink", []*FlexItem{{Basis: 8}, {Basis: 8, Shrink: 1}}, 10, 0, []float64{8, 2}},
+		{"shrink to nothing", []*FlexItem{{Basis: 8, Shrink: 1}, {Basis: 8, Shrink: 1}}, 0, 2, []float64{0, 0}},
+	}
+	for _, test := range tests {
+		if got := flexSizes(test.line, test.size, test.gap); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: flexSizes = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+// flexItems returns FlexItems holding Blocks, with the given basis and grow.
+func flexItems(basis int, grow float64, n int) []*FlexItem {
+	items := make([]*FlexItem, n)
+	for i := range items {
+		items[i] = &FlexItem{Drawable: NewBlock(), Basis: basis, Grow: grow}
+	}
+	return items
+}
+
+func flexRects(flex *Flex) []image.Rectangle {
+	result := make([]image.Rectangle, len(flex.Items))
+	for i, item := range flex.Items {
+		result[i] = item.GetRect()
+	}
+	return result
+}
+
+func TestFlexLayout(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(*Flex)
+		want  []image.Rectangle
+	}{
+		{"start", func(f *Flex) {
+			f.Add(flexItems(4, 0, 2)...)
+		}, []image.Rectangle{image.Rect(0, 0, 4, 3), image.Rect(4, 0, 8, 3)}},
+		{"end", func(f *Flex) {
+			f.Justify = JustifyEnd
+			f.Add(flexItems(4, 0, 2)...)
+		}, []image.Rectangle{image.Rect(12, 0, 16, 3), image.Rect(16, 0, 20, 3)}},
+		{"center", func(f *Flex) {
+			f.Justify = JustifyCenter
+			f.Add(flexItems(4, 0, 2)...)
+		}, []image.Rectangle{image.Rect(6, 0, 10, 3), image.Rect(10, 0, 14, 3)}},
+		{"space between", func(f *Flex) {
+			f.Justify = JustifySpaceBetween
+			f.Add(flexItems(4, 0, 2)...)
+		}, []image.Rectangle{image.Rect(0, 0, 4, 3), image.Rect(16, 0, 20, 3)}},
+		{"space around", func(f *Flex) {
+			f.Justify = JustifySpaceAround
+			f.Add(flexItems(4, 0, 2)...)
+		}, []image.Rectangle{image.Rect(3, 0, 7, 3), image.Rect(13, 0, 17, 3)}},
+		{"space evenly", func(f *Flex) {
+			f.Justify = JustifySpaceEvenly
+			f.Add(flexItems(4, 0, 2)...)
+		}, []image.Rectangle{image.Rect(4, 0, 8, 3), image.Rect(12, 0, 16, 3)}},
+		{"justify without free space", func(f *Flex) {
+			f.Justify = JustifyCenter
+			f.Add(flexItems(4, 1, 2)...)
+		}, []image.Rectangle{image.Rect(0, 0, 10, 3), image.Rect(10, 0, 20, 3)}},
+		{"grow with a gap", func(f *Flex) {
+			f.Gap = 2
+			f.Add(flexItems(0, 1, 3)...)
+		}, []image.Rectangle{image.Rect(0, 0, 5, 3), image.Rect(7, 0, 13, 3), image.Rect(15, 0, 20, 3)}},
+		{"overflow is clipped", func(f *Flex) {
+			f.Add(flexItems(15, 0, 2)...)
+		}, []image.Rectangle{image.Rect(0, 0, 15, 3), image.Rect(15, 0, 20, 3)}},
+		{"align", func(f *Flex) {
+			f.Align = AlignItemsEnd
+			items := flexItems(5, 0, 2)
+			items[0].CrossSize = 1
+			f.Add(items...)
+		}, []image.Rectangle{image.Rect(0, 2, 5, 3), image.Rect(5, 0, 10, 3)}},
+		{"align center", func(f *Flex) {
+			f.Align = AlignItemsCenter
+			items := flexItems(5, 0, 1)
+			items[0].CrossSize = 1
+			f.Add(items...)
+		}, []image.Rectangle{image.Rect(0, 1, 5, 2)}},
+		{"stretch ignores CrossSize", func(f *Flex) {
+			items := flexItems(5, 0, 1)
+			items[0].CrossSize = 1
+			f.Add(items...)
+		}, []image.Rectangle{image.Rect(0, 0, 5, 3)}},
+		{"wrap", func(f *Flex) {
+			f.Wrap = true
+			f.Gap = 1
+			f.Add(flexItems(8, 1, 3)...)
+		}, []image.Rectangle{image.Rect(0, 0, 10, 1), image.Rect(11, 0, 20, 1), image.Rect(0, 2, 20, 3)}},
+		{"wrap with aligned lines", func(f *Flex) {
+			f.Wrap = true
+			f.Align = AlignItemsStart
+			items := flexItems(12, 0, 2)
+			items[1].CrossSize = 2
+			f.Add(items...)
+		}, []image.Rectangle{image.Rect(0, 0, 12, 1), image.Rect(0, 1, 12, 3)}},
+		{"column", func(f *Flex) {
+			f.Direction = FlexColumn
+			items := flexItems(1, 0, 2)
+			items[1].Grow = 1
+			f.Add(items...)
+		}, []image.Rectangle{image.Rect(0, 0, 20, 1), image.Rect(0, 1, 20, 3)}},
+		{"padding", func(f *Flex) {
+			f.PaddingLeft, f.PaddingTop, f.PaddingRight = 2, 1, 3
+			f.Add(flexItems(0, 1, 1)...)
+		}, []image.Rectangle{image.Rect(2, 1, 17, 3)}},
+		{"border", func(f *Flex) {
+			f.Border = true
+			f.SetRect(0, 0, 20, 3)
+			f.Add(flexItems(0, 1, 2)...)
+		}, []image.Rectangle{image.Rect(1, 1, 10, 2), image.Rect(10, 1, 19, 2)}},
+	}
+	for _, test := range tests {
+		flex := NewFlex(FlexRow)
+		flex.SetRect(0, 0, 20, 3)
+		test.setup(flex)
+		flex.Layout()
+		if got := flexRects(flex); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got rectangles %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+// TestFlexTiles checks that adjacent items that fill their lines never overlap or leave gaps.
+func TestFlexTiles(t *testing.T) {
+	for _, direction := range []FlexDirection{FlexRow, FlexColumn} {
+		for width := 1; width < 50; width++ {
+			for height := 1; height < 10; height++ {
+				flex := NewFlex(direction)
+				flex.SetRect(3, 2, 3+width, 2+height)
+				flex.Wrap = true
+				flex.Add(flexItems(4, 1, 7)...)
+				flex.Items[2].Grow = 2.5
+				flex.Items[4].Shrink = 1
+				flex.Items[5].MinSize = 2
+				flex.Draw(NewBuffer(flex.GetRect()))
+				checkTiles(t, flex.Rectangle, flexRects(flex))
+			}
+		}
+	}
+}
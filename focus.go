@@ -0,0 +1,163 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"sync"
+)
+
+// Focusable is a Drawable that handles events while it has focus.
+// Widgets embedding Block get SetFocused from it, and only need to implement HandleEvent.
+type Focusable interface {
+	Drawable
+	// SetFocused is called when the Drawable gains or loses focus.
+	SetFocused(bool)
+	// HandleEvent is called with events received while the Drawable has focus.
+	// Returns whether the event was handled.
+	HandleEvent(Event) bool
+}
+
+// FocusChain tracks which of its Focusables has focus and routes events to it.
+// Focus moves forward through the chain with NextKeys and backward with PrevKeys, wrapping around.
+type FocusChain struct {
+	NextKeys []string
+	PrevKeys []string
+
+	items []Focusable
+	index int
+	lock  sync.Mutex
+}
+
+// NewFocusChain returns a FocusChain of the given Focusables, with focus on the first one.
+func NewFocusChain(items ...Focusable) *FocusChain {
+	self := &FocusChain{
+		NextKeys: []string{"<Tab>"},
+		PrevKeys: []string{"<S-<Tab>>"},
+		index:    -1,
+	}
+	self.Add(items...)
+	return self
+}
+
+// Add appends Focusables to the chain. If nothing has focus, the first of them gets it.
+func (self *FocusChain) Add(items ...Focusable) {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	self.items = append(self.items, items...)
+	if self.index < 0 && len(self.items) > 0 {
+		self.focus(0)
+	}
+}
+
+// Remove removes a Focusable from the chain. If it had focus, the next one gets it.
+func (self *FocusChain) Remove(item Focusable) {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	for i, it := range self.items {
+		if it != item {
+			continue
+		}
+		if i == self.index {
+			setFocused(item, false)
+		}
+		self.items = append(self.items[:i], self.items[i+1:]...)
+		switch {
+		case len(self.items) == 0:
+			self.index = -1
+		case i == self.index:
+			self.index = -1
+			self.focus(i % len(self.items))
+		case i < self.index:
+			self.index--
+		}
+		return
+	}
+}
+
+func setFocused(item Focusable, focused bool) {
+	item.Lock()
+	item.SetFocused(focused)
+	item.Unlock()
+}
+
+func (self *FocusChain) focus(index int) {
+	if index == self.index {
+		return
+	}
+	if self.index >= 0 {
+		setFocused(self.items[self.index], false)
+	}
+	self.index = index
+	setFocused(self.items[index], true)
+}
+
+// Focused returns the Focusable that has focus, or nil if the chain is empty.
+func (self *FocusChain) Focused() Focusable {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	if self.index < 0 {
+		return nil
+	}
+	return self.items[self.index]
+}
+
+// Focus gives focus to a Focusable of the chain. Returns false if it is not in the chain.
+func (self *FocusChain) Focus(item Focusable) bool {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	for i, it := range self.items {
+		if it == item {
+			self.focus(i)
+			return true
+		}
+	}
+	return false
+}
+
+// Next moves focus to the next Focusable of the chain.
+func (self *FocusChain) Next() {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	if len(self.items) > 0 {
+		self.focus((self.index + 1) % len(self.items))
+	}
+}
+
+// Prev moves focus to the previous Focusable of the chain.
+func (self *FocusChain) Prev() {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	if len(self.items) > 0 {
+		self.focus((self.index - 1 + len(self.items)) % len(self.items))
+	}
+}
+
+// HandleEvent moves focus on NextKeys and PrevKeys, and passes any other keyboard event to the focused
+// Focusable. Returns whether the event was handled.
+func (self *FocusChain) HandleEvent(e Event) bool {
+	if e.Type != KeyboardEvent {
+		return false
+	}
+	for _, id := range self.NextKeys {
+		if e.ID == id {
+			self.Next()
+			return true
+		}
+	}
+	for _, id := range self.PrevKeys {
+		if e.ID == id {
+			self.Prev()
+			return true
+		}
+	}
+
+	item := self.Focused()
+	if item == nil {
+		return false
+	}
+	item.Lock()
+	defer item.Unlock()
+	return item.HandleEvent(e)
+}
@@ -0,0 +1,152 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"time"
+)
+
+// Gestures recognizes drags and multiple clicks in a stream of mouse events.
+// It keeps the state of the gestures in progress, so it should only be given the events of one goroutine.
+//
+// Moving the mouse while a button is held produces a <MouseDragStart> event, then a <MouseDragMove> event
+// for each further move, and a <MouseDragEnd> event when the button is released, all with a MouseDrag payload.
+// Pressing a button again at the same position within ClickInterval produces a <MouseDoubleClick> event,
+// and a third time a <MouseTripleClick> event, with a MouseClick payload.
+type Gestures struct {
+	// ClickInterval is the longest time between the presses of a double or triple click.
+	ClickInterval time.Duration
+
+	button   string
+	start    Mouse
+	dragging bool
+
+	clickButton string
+	clickPos    Mouse
+	clickTime   time.Time
+	clicks      int
+}
+
+func NewGestures() *Gestures {
+	return &Gestures{
+		ClickInterval: 500 * time.Millisecond,
+	}
+}
+
+var gestureButtons = map[string]bool{
+	"<MouseLeft>":   true,
+	"<MouseMiddle>": true,
+	"<MouseRight>":  true,
+}
+
+// Recognize returns the gesture events completed by an event, if there are any.
+func (self *Gestures) Recognize(e Event) []Event {
+	mouse, ok := e.Payload.(Mouse)
+	if !ok || e.Type != MouseEvent {
+		return nil
+	}
+
+	switch {
+	case gestureButtons[e.ID] && mouse.Drag:
+		if self.button == "" {
+			// the press was not seen, so the drag starts here
+			self.button = e.ID
+			self.start = mouse
+		}
+		if mouse.X == self.start.X && mouse.Y == self.start.Y && !self.dragging {
+			return nil
+		}
+		id := "<MouseDragMove>"
+		if !self.dragging {
+			id = "<MouseDragStart>"
+			self.dragging = true
+			self.clicks = 0
+		}
+		return []Event{self.dragEvent(id, mouse)}
+
+	case gestureButtons[e.ID]:
+		self.button = e.ID
+		self.start = mouse
+		self.dragging = false
+
+		now := time.Now()
+		if self.clicks > 0 && self.clicks < 3 && e.ID == self.clickButton &&
+			mouse.X == self.clickPos.X && mouse.Y == self.clickPos.Y &&
+			now.Sub(self.clickTime) <= self.ClickInterval {
+			self.clicks++
+		} else {
+			self.clicks = 1
+		}
+		self.clickButton = e.ID
+		self.clickPos = mouse
+		self.clickTime = now
+
+		switch self.clicks {
+		case 2:
+			return []Event{self.clickEvent("<MouseDoubleClick>", mouse)}
+		case 3:
+			return []Event{self.clickEvent("<MouseTripleClick>", mouse)}
+		}
+
+	case e.ID == "<MouseRelease>" && !mouse.Drag:
+		var events []Event
+		if self.dragging {
+			events = []Event{self.dragEvent("<MouseDragEnd>", mouse)}
+		}
+		self.button = ""
+		self.dragging = false
+		return events
+	}
+	return nil
+}
+
+func (self *Gestures) dragEvent(id string, mouse Mouse) Event {
+	return Event{
+		Type: MouseEvent,
+		ID:   id,
+		Payload: MouseDrag{
+			Button: self.button,
+			StartX: self.start.X,
+			StartY: self.start.Y,
+			X:      mouse.X,
+			Y:      mouse.Y,
+			Ctrl:   mouse.Ctrl,
+			Alt:    mouse.Alt,
+			Shift:  mouse.Shift,
+		},
+	}
+}
+
+func (self *Gestures) clickEvent(id string, mouse Mouse) Event {
+	return Event{
+		Type: MouseEvent,
+		ID:   id,
+		Payload: MouseClick{
+			Button: self.clickButton,
+			Count:  self.clicks,
+			X:      mouse.X,
+			Y:      mouse.Y,
+			Ctrl:   mouse.Ctrl,
+			Alt:    mouse.Alt,
+			Shift:  mouse.Shift,
+		},
+	}
+}
+
+// Wrap returns a channel with the events of another channel, like the one of PollEvents, each followed by the
+// gesture events it completes.
+func (self *Gestures) Wrap(events <-chan Event) <-chan Event {
+	ch := make(chan Event)
+	go func() {
+		defer close(ch)
+		for e := range events {
+			ch <- e
+			for _, gesture := range self.Recognize(e) {
+				ch <- gesture
+			}
+		}
+	}()
+	return ch
+}
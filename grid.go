@@ -4,6 +4,11 @@
 
 package termui
 
+import (
+	"image"
+	"math"
+)
+
 type gridItemType uint
 
 const (
@@ -14,12 +19,15 @@ const (
 type Grid struct {
 	Block
 	Items []*GridItem
+	roots []GridItem
 }
 
 // GridItem represents either a Row or Column in a grid.
 // Holds sizing information and either an []GridItems or a widget.
+// The ratios are only set for items sized with a ratio or percentage.
 type GridItem struct {
 	Type        gridItemType
+	Size        GridSize
 	XRatio      float64
 	YRatio      float64
 	WidthRatio  float64
@@ -29,6 +37,120 @@ type GridItem struct {
 	ratio       float64
 }
 
+type gridSizeKind uint
+
+const (
+	sizeRatio gridSizeKind = iota
+	sizeFixed
+	sizeFill
+)
+
+// GridSize is the size of a Row along the height of its parent or of a Column along its width.
+// Sizes are fixed, a percentage of the parent, or a share of what is left after the other sizes,
+// and can be limited to a minimum and maximum number of cells.
+type GridSize struct {
+	kind  gridSizeKind
+	value float64
+	min   int
+	max   int
+}
+
+// FixedSize is a size of exactly n cells.
+func FixedSize(n int) GridSize {
+	return GridSize{kind: sizeFixed, value: float64(n)}
+}
+
+// PercentSize is a size of a percentage of the parent.
+func PercentSize(percent float64) GridSize {
+	return GridSize{kind: sizeRatio, value: percent / 100}
+}
+
+// FillSize is a share of the cells left by the fixed and percentage sizes of the parent, divided between
+// the fill sizes in proportion to their weights.
+func FillSize(weight float64) GridSize {
+	return GridSize{kind: sizeFill, value: weight}
+}
+
+// WithMin returns the size limited to at least n cells.
+func (self GridSize) WithMin(n int) GridSize {
+	self.min = n
+	return self
+}
+
+// WithMax returns the size limited to at most n cells.
+func (self GridSize) WithMax(n int) GridSize {
+	self.max = n
+	return self
+}
+
+func (self GridSize) clamp(cells float64) float64 {
+	if self.max > 0 && cells > float64(self.max) {
+		cells = float64(self.max)
+	}
+	if cells < float64(self.min) {
+		cells = float64(self.min)
+	}
+	return cells
+}
+
+// distributeSizes divides a number of cells between sizes. Fixed sizes and percentages are taken first, and
+// the fill sizes share the rest, with those that reach a limit held at it. The sizes are rounded so that
+// each item starts where the previous one ends: they only add up to more or less than total if the fixed
+// sizes, percentages and limits do.
+func distributeSizes(total int, sizes []GridSize) []int {
+	exact := make([]float64, len(sizes))
+	free := float64(total)
+	var fills []int
+	for i, size := range sizes {
+		switch size.kind {
+		case sizeFixed:
+			exact[i] = size.clamp(size.value)
+		case sizeRatio:
+			exact[i] = size.clamp(size.value * float64(total))
+		case sizeFill:
+			fills = append(fills, i)
+			continue
+		}
+		free -= exact[i]
+	}
+
+	for len(fills) > 0 {
+		weights := 0.0
+		for _, i := range fills {
+			weights += math.Max(sizes[i].value, 0)
+		}
+		share := math.Max(free, 0)
+		var unlimited []int
+		for _, i := range fills {
+			cells := 0.0
+			if weights > 0 {
+				cells = share * math.Max(sizes[i].value, 0) / weights
+			}
+			exact[i] = sizes[i].clamp(cells)
+			if exact[i] != cells {
+				free -= exact[i]
+			} else {
+				unlimited = append(unlimited, i)
+			}
+		}
+		if len(unlimited) == len(fills) {
+			break
+		}
+		fills = unlimited
+	}
+
+	cells := make([]int, len(sizes))
+	end := 0.0
+	prev := 0
+	for i, size := range exact {
+		end += size
+		next := int(math.Round(end))
+		cells[i] = next - prev
+		prev = next
+	}
+	return cells
+}
+
 func NewGrid() *Grid {
 	g := &Grid{
 		Block: *NewBlock(),
@@ -37,34 +159,42 @@ func NewGrid() *Grid {
 	return g
 }
 
-// NewCol takes a height percentage and either a widget or a Row or Column
+// NewCol takes a width ratio and either a widget or a Row or Column
 func NewCol(ratio float64, i ...interface{}) GridItem {
-	_, ok := i[0].(Drawable)
-	entry := i[0]
-	if !ok {
-		entry = i
-	}
-	return GridItem{
-		Type:   col,
-		Entry:  entry,
-		IsLeaf: ok,
-		ratio:  ratio,
-	}
+	return NewColSize(GridSize{kind: sizeRatio, value: ratio}, i...)
 }
 
-// NewRow takes a width percentage and either a widget or a Row or Column
+// NewRow takes a height ratio and either a widget or a Row or Column
 func NewRow(ratio float64, i ...interface{}) GridItem {
+	return NewRowSize(GridSize{kind: sizeRatio, value: ratio}, i...)
+}
+
+// NewColSize is like NewCol, but takes a GridSize for the width.
+func NewColSize(size GridSize, i ...interface{}) GridItem {
+	return newGridItem(col, size, i)
+}
+
+// NewRowSize is like NewRow, but takes a GridSize for the height.
+func NewRowSize(size GridSize, i ...interface{}) GridItem {
+	return newGridItem(row, size, i)
+}
+
+func newGridItem(itemType gridItemType, size GridSize, i []interface{}) GridItem {
 	_, ok := i[0].(Drawable)
 	entry := i[0]
 	if !ok {
 		entry = i
 	}
-	return GridItem{
-		Type:   row,
+	item := GridItem{
+		Type:   itemType,
+		Size:   size,
 		Entry:  entry,
 		IsLeaf: ok,
-		ratio:  ratio,
 	}
+	if size.kind == sizeRatio {
+		item.ratio = size.value
+	}
+	return item
 }
 
 // Set is used to add Columns and Rows to the grid.
@@ -72,10 +202,12 @@ func NewRow(ratio float64, i ...interface{}) GridItem {
 func (self *Grid) Set(entries ...interface{}) {
 	entry := GridItem{
 		Type:   row,
+		Size:   PercentSize(100),
 		Entry:  entries,
 		IsLeaf: false,
 		ratio:  1.0,
 	}
+	self.roots = append(self.roots, entry)
 	self.setHelper(entry, 1.0, 1.0)
 }
 
@@ -132,29 +264,72 @@ func (self *Grid) setHelper(item GridItem, parentWidthRatio, parentHeightRatio f
 	}
 }
 
-func (self *Grid) Draw(buf *Buffer) {
-	width := float64(self.Dx()) + 1
-	height := float64(self.Dy()) + 1
-
-	for _, item := range self.Items {
+// layout sets the rectangles of the widgets of a GridItem inside a rectangle. The children of an item are
+// placed side by side if the first of them is a Column, and stacked if it is a Row.
+func (self *Grid) layout(item GridItem, rect image.Rectangle) {
+	if item.IsLeaf {
 		entry, _ := item.Entry.(Drawable)
+		entry.SetRect(rect.Min.X, rect.Min.Y, rect.Max.X, rect.Max.Y)
+		return
+	}
 
-		x := int(width*item.XRatio) + self.Min.X
-		y := int(height*item.YRatio) + self.Min.Y
-		w := int(width * item.WidthRatio)
-		h := int(height * item.HeightRatio)
-
-		if x+w > self.Dx() {
-			w--
+	var children []GridItem
+	var sizes []GridSize
+	for _, child := range InterfaceSlice(item.Entry) {
+		if child, ok := child.(GridItem); ok {
+			children = append(children, child)
+			sizes = append(sizes, child.Size)
 		}
-		if y+h > self.Dy() {
-			h--
+	}
+	if len(children) == 0 {
+		return
+	}
+
+	cols := children[0].Type == col
+	total := rect.Dy()
+	if cols {
+		total = rect.Dx()
+	}
+	cells := distributeSizes(total, sizes)
+
+	start := rect.Min
+	for i, child := range children {
+		childRect := image.Rectangle{start, rect.Max}
+		if cols {
+			childRect.Max.X = start.X + cells[i]
+			start.X += cells[i]
+		} else {
+			childRect.Max.Y = start.Y + cells[i]
+			start.Y += cells[i]
 		}
+		self.layout(child, childRect.Intersect(rect))
+	}
+}
+
+func (self *Grid) Draw(buf *Buffer) {
+	for _, root := range self.roots {
+		self.layout(root, self.Rectangle)
+	}
 
-		entry.SetRect(x, y, x+w, y+h)
+	for _, item := range self.Items {
+		entry, _ := item.Entry.(Drawable)
+		if entry.GetRect().Empty() {
+			continue
+		}
 
 		entry.Lock()
-		entry.Draw(buf)
+		entry.Draw(buf.Sub(entry.GetRect()))
 		entry.Unlock()
 	}
 }
+
+// Children implements the Container interface.
+func (self *Grid) Children() []Drawable {
+	children := []Drawable{}
+	for _, item := range self.Items {
+		if entry, ok := item.Entry.(Drawable); ok {
+			children = append(children, entry)
+		}
+	}
+	return children
+}
@@ -0,0 +1,142 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"image"
+	"reflect"
+	"testing"
+)
+
+func TestDistributeSizes(t *testing.T) {
+	tests := []struct {
+		name  string
+		total int
+		sizes []GridSize
+		want  []int
+	}{
+		{"fixed", 10, []GridSize{FixedSize(3), FixedSize(7)}, []int{3, 7}},
+		{"fixed and fill", 80, []GridSize{FixedSize(30), FillSize(1)}, []int{30, 50}},
+		{"fill weights", 90, []GridSize{FillSize(1), FillSize(2)}, []int{30, 60}},
+		{"thirds are rounded without gaps", 100, []GridSize{PercentSize(100.0 / 3), PercentSize(100.0 / 3), PercentSize(100.0 / 3)}, []int{33, 34, 33}},
+		{"fills are rounded without gaps", 10, []GridSize{FillSize(1), FillSize(1), FillSize(1)}, []int{3, 4, 3}},
+		{"percent and fill", 50, []GridSize{PercentSize(50), FixedSize(5), FillSize(1)}, []int{25, 5, 20}},
+		{"fill at its max", 40, []GridSize{FillSize(1).WithMax(10), FillSize(1)}, []int{10, 30}},
+		{"fill at its min", 20, []GridSize{FillSize(1).WithMin(15), FillSize(1)}, []int{15, 5}},
+		{"percent at its min", 20, []GridSize{PercentSize(10).WithMin(5), FillSize(1)}, []int{5, 15}},
+		{"fixed at its max", 20, []GridSize{FixedSize(30).WithMax(12), FillSize(1)}, []int{12, 8}},
+		{"overflow leaves nothing to fill", 10, []GridSize{FixedSize(8), FixedSize(5), FillSize(1)}, []int{8, 5, 0}},
+		{"percentages that do not add up", 10, []GridSize{PercentSize(30), PercentSize(30)}, []int{3, 3}},
+		{"zero weights get nothing", 10, []GridSize{FillSize(0), FillSize(1)}, []int{0, 10}},
+		{"empty", 0, []GridSize{FillSize(1), FixedSize(0)}, []int{0, 0}},
+	}
+	for _, test := range tests {
+		if got := distributeSizes(test.total, test.sizes); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: distributeSizes(%d) = %v, want %v", test.name, test.total, got, test.want)
+		}
+	}
+}
+
+// blocks returns n Blocks to lay out.
+func blocks(n int) []*Block {
+	items := make([]*Block, n)
+	for i := range items {
+		items[i] = NewBlock()
+	}
+	return items
+}
+
+// rects returns the rectangles of Blocks.
+func rects(items []*Block) []image.Rectangle {
+	result := make([]image.Rectangle, len(items))
+	for i, item := range items {
+		result[i] = item.GetRect()
+	}
+	return result
+}
+
+func TestGridLayout(t *testing.T) {
+	b := blocks(6)
+	grid := NewGrid()
+	grid.SetRect(0, 0, 101, 31)
+	grid.Set(
+		NewRowSize(FixedSize(1), b[0]),
+		NewRowSize(FillSize(1),
+			NewColSize(FixedSize(30), b[1]),
+			NewColSize(FillSize(1),
+				NewRow(1.0/3, b[2]),
+				NewRow(1.0/3, b[3]),
+				NewRow(1.0/3, b[4]),
+			),
+		),
+		NewRowSize(PercentSize(10).WithMin(4), b[5]),
+	)
+	grid.Draw(NewBuffer(grid.GetRect()))
+
+	want := []image.Rectangle{
+		image.Rect(0, 0, 101, 1),
+		image.Rect(0, 1, 30, 27),
+		image.Rect(30, 1, 101, 10),
+		image.Rect(30, 10, 101, 18),
+		image.Rect(30, 18, 101, 27),
+		image.Rect(0, 27, 101, 31),
+	}
+	if got := rects(b); !reflect.DeepEqual(got, want) {
+		t.Errorf("got rectangles %v, want %v", got, want)
+	}
+}
+
+// checkTiles fails unless the non-empty rectangles cover every cell of area exactly once.
+func checkTiles(t *testing.T, area image.Rectangle, rects []image.Rectangle) {
+	t.Helper()
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		for x := area.Min.X; x < area.Max.X; x++ {
+			covered := 0
+			for _, r := range rects {
+				if image.Pt(x, y).In(r) {
+					covered++
+				}
+			}
+			if covered != 1 {
+				t.Fatalf("cell (%d,%d) of %v is covered %d times by %v", x, y, area, covered, rects)
+			}
+		}
+	}
+	for _, r := range rects {
+		if !r.Empty() && !r.In(area) {
+			t.Fatalf("%v is outside of %v", r, area)
+		}
+	}
+}
+
+// TestGridTiles checks that adjacent items never overlap or leave gaps, whatever the size of the Grid.
+func TestGridTiles(t *testing.T) {
+	for width := 1; width < 60; width++ {
+		for height := 1; height < 20; height += 3 {
+			b := blocks(5)
+			grid := NewGrid()
+			grid.SetRect(2, 1, 2+width, 1+height)
+			grid.Set(
+				NewRow(1.0/3,
+					NewCol(1.0/3, b[0]),
+					NewCol(2.0/3, b[1]),
+				),
+				NewRowSize(FillSize(2),
+					NewColSize(FixedSize(7), b[2]),
+					NewColSize(PercentSize(30).WithMin(3), b[3]),
+					NewColSize(FillSize(1).WithMax(20), b[4]),
+				),
+			)
+			grid.Draw(NewBuffer(grid.GetRect()))
+			r := rects(b)
+			if width < 7+3+20 {
+				checkTiles(t, grid.Rectangle, r)
+			} else {
+				// the last column is held at its max, which leaves the end of the row empty
+				checkTiles(t, grid.Rectangle, append(r, image.Rect(r[4].Max.X, r[4].Min.Y, grid.Max.X, grid.Max.Y)))
+			}
+		}
+	}
+}
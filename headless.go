@@ -0,0 +1,281 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	rw "github.com/mattn/go-runewidth"
+)
+
+// HeadlessBackend is a Backend that draws to an in-memory screen instead of a terminal.
+// It can be used to test widgets and dashboards without a TTY:
+//
+//	screen := NewHeadlessBackend(80, 24)
+//	InitWithBackend(screen)
+//	Render(table)
+//	err := screen.CompareGolden("testdata/table.golden")
+type HeadlessBackend struct {
+	width, height int
+	back          []Cell
+	front         []Cell
+	events        chan Event
+	done          chan struct{}
+	lock          sync.Mutex
+}
+
+func NewHeadlessBackend(width, height int) *HeadlessBackend {
+	self := &HeadlessBackend{
+		events: make(chan Event, 64),
+	}
+	self.resize(width, height)
+	return self
+}
+
+func (self *HeadlessBackend) resize(width, height int) {
+	self.width, self.height = width, height
+	self.back = make([]Cell, width*height)
+	self.front = make([]Cell, width*height)
+	for i := range self.back {
+		self.back[i] = CellClear
+		self.front[i] = CellClear
+	}
+}
+
+// Init implements the Backend interface.
+func (self *HeadlessBackend) Init() error {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	self.done = make(chan struct{})
+	return nil
+}
+
+// Close implements the Backend interface.
+func (self *HeadlessBackend) Close() {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	if self.done != nil {
+		close(self.done)
+		self.done = nil
+	}
+}
+
+// Size implements the Backend interface.
+func (self *HeadlessBackend) Size() (int, int) {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	return self.width, self.height
+}
+
+// SetCell implements the Backend interface.
+func (self *HeadlessBackend) SetCell(x, y int, c Cell) {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	if x < 0 || y < 0 || x >= self.width || y >= self.height {
+		return
+	}
+	self.back[y*self.width+x] = c
+}
+
+// Flush implements the Backend interface.
+func (self *HeadlessBackend) Flush() error {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	copy(self.front, self.back)
+	return nil
+}
+
+// PollEvent implements the Backend interface.
+// It returns events queued with `PostEvent`, or an empty Event once the backend is closed.
+func (self *HeadlessBackend) PollEvent() Event {
+	self.lock.Lock()
+	done := self.done
+	self.lock.Unlock()
+	select {
+	case e := <-self.events:
+		return e
+	case <-done:
+		return Event{}
+	}
+}
+
+// PostEvent queues an event to be returned by `PollEvent`.
+func (self *HeadlessBackend) PostEvent(e Event) {
+	self.events <- e
+}
+
+// Resize changes the size of the screen, clearing it, and queues a ResizeEvent.
+func (self *HeadlessBackend) Resize(width, height int) {
+	self.lock.Lock()
+	self.resize(width, height)
+	self.lock.Unlock()
+	self.PostEvent(Event{
+		Type: ResizeEvent,
+		ID:   "<Resize>",
+		Payload: Resize{
+			Width:  width,
+			Height: height,
+		},
+	})
+}
+
+// Cell returns the flushed cell at the given position.
+func (self *HeadlessBackend) Cell(x, y int) Cell {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	if x < 0 || y < 0 || x >= self.width || y >= self.height {
+		return CellClear
+	}
+	return self.front[y*self.width+x]
+}
+
+// Lines returns the flushed screen as one string per row.
+// The cell following a double width rune is skipped, so each line has the display width of the screen.
+func (self *HeadlessBackend) Lines() []string {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	lines := make([]string, self.height)
+	for y := 0; y < self.height; y++ {
+		var sb strings.Builder
+		for x := 0; x < self.width; x++ {
+			r := self.front[y*self.width+x].Rune
+			if r == 0 {
+				r = ' '
+			}
+			sb.WriteRune(r)
+			if rw.RuneWidth(r) == 2 {
+				x++
+			}
+		}
+		lines[y] = sb.String()
+	}
+	return lines
+}
+
+// String returns the flushed screen as text.
+func (self *HeadlessBackend) String() string {
+	return strings.Join(self.Lines(), "\n")
+}
+
+// styleKeys are used in order to label the distinct styles of a snapshot.
+const styleKeys = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// Snapshot returns the flushed screen as text, followed by a grid labelling the style of each cell
+// and a legend for the labels. Cells with StyleClear are labelled with '.'.
+func (self *HeadlessBackend) Snapshot() string {
+	lines := self.Lines()
+
+	self.lock.Lock()
+	defer self.lock.Unlock()
+
+	keys := map[Style]rune{StyleClear: '.'}
+	styles := []Style{}
+	var sb strings.Builder
+
+	sb.WriteString("text:\n")
+	for _, line := range lines {
+		sb.WriteString("|" + line + "|\n")
+	}
+
+	sb.WriteString("styles:\n")
+	for y := 0; y < self.height; y++ {
+		sb.WriteRune('|')
+		for x := 0; x < self.width; x++ {
+			cell := self.front[y*self.width+x]
+			key, ok := keys[cell.Style]
+			if !ok {
+				if len(styles) < len(styleKeys) {
+					key = rune(styleKeys[len(styles)])
+				} else {
+					key = rune(0x100 + len(styles))
+				}
+				keys[cell.Style] = key
+				styles = append(styles, cell.Style)
+			}
+			sb.WriteRune(key)
+		}
+		sb.WriteString("|\n")
+	}
+
+	sb.WriteString("legend:\n")
+	for _, style := range styles {
+		sb.WriteString(fmt.Sprintf("%c %s\n", keys[style], styleString(style)))
+	}
+
+	return sb.String()
+}
+
+// WriteGolden writes the current snapshot to the given file.
+func (self *HeadlessBackend) WriteGolden(path string) error {
+	return ioutil.WriteFile(path, []byte(self.Snapshot()), 0644)
+}
+
+// CompareGolden compares the current snapshot to the one stored in the given file,
+// returning an error describing the first difference if they do not match.
+func (self *HeadlessBackend) CompareGolden(path string) error {
+	golden, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	want := strings.Split(string(golden), "\n")
+	got := strings.Split(self.Snapshot(), "\n")
+	for i := 0; i < len(want) || i < len(got); i++ {
+		var w, g string
+		if i < len(want) {
+			w = want[i]
+		}
+		if i < len(got) {
+			g = got[i]
+		}
+		if w != g {
+			return fmt.Errorf("snapshot does not match %s at line %d:\n  want: %q\n  got:  %q", path, i+1, w, g)
+		}
+	}
+	return nil
+}
+
+// styleString formats a Style using the same syntax as `ParseStyles`.
+func styleString(style Style) string {
+	items := []string{}
+	if style.Fg != ColorClear {
+		items = append(items, tokenFg+tokenValueSeparator+colorString(style.Fg))
+	}
+	if style.Bg != ColorClear {
+		items = append(items, tokenBg+tokenValueSeparator+colorString(style.Bg))
+	}
+	if style.Modifier != ModifierClear {
+		names := []string{}
+		for name, modifier := range modifierMap {
+			if style.Modifier&modifier != 0 {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		items = append(items, tokenModifier+tokenValueSeparator+strings.Join(names, tokenModifierSeparator))
+	}
+	if len(items) == 0 {
+		return "clear"
+	}
+	return strings.Join(items, tokenItemSeparator)
+}
+
+func colorString(color Color) string {
+	names := []string{}
+	for name, c := range StyleParserColorMap {
+		if c == color {
+			names = append(names, name)
+		}
+	}
+	if len(names) > 0 {
+		sort.Strings(names)
+		return names[0]
+	}
+	return strconv.Itoa(int(color))
+}
@@ -0,0 +1,41 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"image"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCompareGolden(t *testing.T) {
+	screen := NewHeadlessBackend(6, 2)
+	if err := InitWithBackend(screen); err != nil {
+		t.Fatal(err)
+	}
+	defer Close()
+
+	block := NewBlock()
+	block.SetRect(0, 0, 6, 2)
+	Render(block)
+	path := filepath.Join(t.TempDir(), "block.golden")
+	if err := screen.WriteGolden(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := screen.CompareGolden(path); err != nil {
+		t.Errorf("snapshot does not match the golden file it was written to: %v", err)
+	}
+
+	block.Title = "x"
+	Render(block)
+	err := screen.CompareGolden(path)
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("got error %v, want a difference at line 2", err)
+	}
+	if got := screen.Cell(2, 0); got.Rune != 'x' {
+		t.Errorf("got cell %v at %v, want the title", got, image.Pt(2, 0))
+	}
+}
@@ -0,0 +1,308 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// Binding is a sequence of keys bound to a handler in a Keymap.
+type Binding struct {
+	Mode        string
+	Keys        string
+	Description string
+	Handler     func(Event)
+}
+
+type keymapNode struct {
+	children map[string]*keymapNode
+	binding  *Binding
+}
+
+func newKeymapNode() *keymapNode {
+	return &keymapNode{
+		children: make(map[string]*keymapNode),
+	}
+}
+
+// Keymap dispatches keyboard events to handlers bound to sequences of keys, like `gg`, `dd` or `<C-w>h`,
+// written with the IDs of keyboard events.
+// Bindings belong to a mode, like "normal" or "insert", and only the bindings of the current mode and
+// those of the empty mode, which are active in every mode, are matched.
+type Keymap struct {
+	// Timeout is how long the Keymap waits for the next key of a sequence. When it elapses, the keys
+	// pressed so far are dispatched if they are bound, or discarded.
+	Timeout time.Duration
+	// Unhandled is called by Run with the events that are not part of a binding.
+	Unhandled func(Event)
+
+	mode      string
+	modes     map[string]*keymapNode
+	bindings  []*Binding
+	pending   []string
+	lastEvent Event
+	lastTime  time.Time
+	lock      sync.Mutex
+}
+
+func NewKeymap() *Keymap {
+	return &Keymap{
+		Timeout: time.Second,
+		modes:   make(map[string]*keymapNode),
+	}
+}
+
+// ParseKeys splits a sequence of keys like `<C-w>h` or `gg` into the IDs of its keyboard events.
+func ParseKeys(keys string) []string {
+	ids := []string{}
+	runes := []rune(keys)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] == '<' {
+			depth := 0
+			for j := i; j < len(runes); j++ {
+				if runes[j] == '<' {
+					depth++
+				} else if runes[j] == '>' {
+					depth--
+				}
+				if depth == 0 {
+					ids = append(ids, string(runes[i:j+1]))
+					i = j
+					break
+				}
+			}
+			if depth == 0 {
+				continue
+			}
+		}
+		ids = append(ids, string(runes[i]))
+	}
+	return ids
+}
+
+// Bind binds a sequence of keys in a mode to a handler, replacing any previous binding of the same keys.
+// The handler is called with the last event of the sequence. Bindings in the empty mode are active in
+// every mode.
+func (self *Keymap) Bind(mode, keys, description string, handler func(Event)) {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+
+	node, ok := self.modes[mode]
+	if !ok {
+		node = newKeymapNode()
+		self.modes[mode] = node
+	}
+	for _, id := range ParseKeys(keys) {
+		child, ok := node.children[id]
+		if !ok {
+			child = newKeymapNode()
+			node.children[id] = child
+		}
+		node = child
+	}
+
+	binding := &Binding{mode, keys, description, handler}
+	if node.binding != nil {
+		for i, b := range self.bindings {
+			if b == node.binding {
+				self.bindings = append(self.bindings[:i], self.bindings[i+1:]...)
+				break
+			}
+		}
+	}
+	node.binding = binding
+	self.bindings = append(self.bindings, binding)
+}
+
+// SetMode changes the current mode and discards any keys pressed so far.
+func (self *Keymap) SetMode(mode string) {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	self.mode = mode
+	self.pending = nil
+}
+
+func (self *Keymap) Mode() string {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	return self.mode
+}
+
+// lookup returns the binding of a sequence of keys in the current mode, and whether the sequence is the
+// prefix of a longer binding.
+func (self *Keymap) lookup(keys []string) (*Binding, bool) {
+	var binding *Binding
+	prefix := false
+	modes := []string{self.mode}
+	if self.mode != "" {
+		modes = append(modes, "")
+	}
+	for _, mode := range modes {
+		node := self.modes[mode]
+		for i := 0; i < len(keys) && node != nil; i++ {
+			node = node.children[keys[i]]
+		}
+		if node == nil {
+			continue
+		}
+		if binding == nil {
+			binding = node.binding
+		}
+		if len(node.children) > 0 {
+			prefix = true
+		}
+	}
+	return binding, prefix
+}
+
+// flush resolves the keys pressed so far, returning the handler to call if they are bound.
+func (self *Keymap) flush() func() {
+	if len(self.pending) == 0 {
+		return nil
+	}
+	binding, _ := self.lookup(self.pending)
+	self.pending = nil
+	if binding == nil {
+		return nil
+	}
+	e := self.lastEvent
+	return func() {
+		binding.Handler(e)
+	}
+}
+
+// Dispatch handles a keyboard event, calling the handler of a binding if the event completes one.
+// Returns whether the event is part of a binding.
+func (self *Keymap) Dispatch(e Event) bool {
+	if e.Type != KeyboardEvent {
+		return false
+	}
+
+	self.lock.Lock()
+	handlers := []func(){}
+	if len(self.pending) > 0 && time.Since(self.lastTime) >= self.Timeout {
+		if handler := self.flush(); handler != nil {
+			handlers = append(handlers, handler)
+		}
+	}
+
+	handled := true
+	keys := append(append([]string{}, self.pending...), e.ID)
+	binding, prefix := self.lookup(keys)
+	if !prefix && binding == nil && len(self.pending) > 0 {
+		// the sequence is broken, so the keys pressed so far are resolved and the event starts a new one
+		if handler := self.flush(); handler != nil {
+			handlers = append(handlers, handler)
+		}
+		keys = []string{e.ID}
+		binding, prefix = self.lookup(keys)
+	}
+	switch {
+	case prefix:
+		self.pending = keys
+		self.lastEvent = e
+		self.lastTime = time.Now()
+	case binding != nil:
+		self.pending = nil
+		handlers = append(handlers, func() {
+			binding.Handler(e)
+		})
+	default:
+		handled = false
+	}
+	self.lock.Unlock()
+
+	for _, handler := range handlers {
+		handler()
+	}
+	return handled
+}
+
+// Flush resolves the keys pressed so far without waiting for Timeout, calling the handler of their binding if there is one.
+func (self *Keymap) Flush() {
+	self.lock.Lock()
+	handler := self.flush()
+	self.lock.Unlock()
+	if handler != nil {
+		handler()
+	}
+}
+
+// Deadline returns when the keys pressed so far time out, if there are any.
+func (self *Keymap) Deadline() (time.Time, bool) {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	if len(self.pending) == 0 {
+		return time.Time{}, false
+	}
+	return self.lastTime.Add(self.Timeout), true
+}
+
+// Run dispatches events, like those of PollEvents, until the channel is closed.
+// Pressed keys are flushed when they time out.
+func (self *Keymap) Run(events <-chan Event) {
+	for {
+		var timeout <-chan time.Time
+		if deadline, ok := self.Deadline(); ok {
+			timeout = time.After(time.Until(deadline))
+		}
+		select {
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			if !self.Dispatch(e) && self.Unhandled != nil {
+				self.Unhandled(e)
+			}
+		case <-timeout:
+			self.Flush()
+		}
+	}
+}
+
+// Bindings returns all bindings sorted by mode and keys.
+func (self *Keymap) Bindings() []Binding {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	bindings := make([]Binding, len(self.bindings))
+	for i, binding := range self.bindings {
+		bindings[i] = *binding
+	}
+	sort.Slice(bindings, func(i, j int) bool {
+		if bindings[i].Mode != bindings[j].Mode {
+			return bindings[i].Mode < bindings[j].Mode
+		}
+		return bindings[i].Keys < bindings[j].Keys
+	})
+	return bindings
+}
+
+// Help returns a table of all bindings and their descriptions, grouped by mode.
+func (self *Keymap) Help() string {
+	var sb strings.Builder
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	mode := ""
+	for i, binding := range self.Bindings() {
+		if i == 0 || binding.Mode != mode {
+			mode = binding.Mode
+			if i > 0 {
+				fmt.Fprintln(w)
+			}
+			if mode == "" {
+				fmt.Fprintln(w, "all modes:")
+			} else {
+				fmt.Fprintf(w, "%s mode:\n", mode)
+			}
+		}
+		fmt.Fprintf(w, "  %s\t%s\n", binding.Keys, binding.Description)
+	}
+	w.Flush()
+	return sb.String()
+}
@@ -0,0 +1,139 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseKeys(t *testing.T) {
+	tests := []struct {
+		keys string
+		want []string
+	}{
+		{"", []string{}},
+		{"q", []string{"q"}},
+		{"gg", []string{"g", "g"}},
+		{"<C-w>h", []string{"<C-w>", "h"}},
+		{"<M-<C-<S-<Up>>>>x", []string{"<M-<C-<S-<Up>>>>", "x"}},
+		{"<Space><Enter>", []string{"<Space>", "<Enter>"}},
+		{"a<b", []string{"a", "<", "b"}},
+		{">", []string{">"}},
+	}
+	for _, test := range tests {
+		if got := ParseKeys(test.keys); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("ParseKeys(%q) = %q, want %q", test.keys, got, test.want)
+		}
+	}
+}
+
+// keyEvents returns the keyboard events of a sequence of keys.
+func keyEvents(keys string) []Event {
+	events := []Event{}
+	for _, id := range ParseKeys(keys) {
+		events = append(events, Event{Type: KeyboardEvent, ID: id})
+	}
+	return events
+}
+
+func TestKeymapDispatch(t *testing.T) {
+	var called []string
+	bind := func(keymap *Keymap, mode, keys string) {
+		keymap.Bind(mode, keys, "", func(e Event) {
+			called = append(called, mode+":"+keys+"@"+e.ID)
+		})
+	}
+
+	tests := []struct {
+		name    string
+		mode    string
+		input   string
+		want    []string
+		handled []bool
+	}{
+		{"single key", "", "j", []string{":j@j"}, []bool{true}},
+		{"unbound key", "", "x", nil, []bool{false}},
+		{"sequence", "", "gg", []string{":gg@g"}, []bool{true, true}},
+		{"prefix of a longer binding waits", "", "<C-w>", nil, []bool{true}},
+		{"sequence with special keys", "", "<C-w>h", []string{":<C-w>h@h"}, []bool{true, true}},
+		// d is bound and a prefix of dd, so it is resolved when the sequence breaks
+		{"broken sequence resolves the prefix", "", "dj", []string{":d@d", ":j@j"}, []bool{true, true}},
+		{"broken sequence discards unbound prefix", "", "gj", []string{":j@j"}, []bool{true, true}},
+		{"mode binding", "insert", "i", []string{"insert:i@i"}, []bool{true}},
+		{"mode binding shadows the empty mode", "insert", "j", []string{"insert:j@j"}, []bool{true}},
+		{"empty mode is active in every mode", "insert", "gg", []string{":gg@g"}, []bool{true, true}},
+		{"other modes are not active", "", "i", nil, []bool{false}},
+	}
+	for _, test := range tests {
+		keymap := NewKeymap()
+		bind(keymap, "", "j")
+		bind(keymap, "", "gg")
+		bind(keymap, "", "<C-w>h")
+		bind(keymap, "", "d")
+		bind(keymap, "", "dd")
+		bind(keymap, "insert", "i")
+		bind(keymap, "insert", "j")
+		keymap.SetMode(test.mode)
+
+		called = nil
+		handled := []bool{}
+		for _, e := range keyEvents(test.input) {
+			handled = append(handled, keymap.Dispatch(e))
+		}
+		if !reflect.DeepEqual(called, test.want) || !reflect.DeepEqual(handled, test.handled) {
+			t.Errorf("%s: got calls %q and handled %v, want %q and %v", test.name, called, handled, test.want, test.handled)
+		}
+	}
+}
+
+func TestKeymapTimeout(t *testing.T) {
+	keymap := NewKeymap()
+	keymap.Timeout = 10 * time.Millisecond
+	var called []string
+	keymap.Bind("", "d", "", func(Event) { called = append(called, "d") })
+	keymap.Bind("", "dd", "", func(Event) { called = append(called, "dd") })
+
+	keymap.Dispatch(Event{Type: KeyboardEvent, ID: "d"})
+	if _, ok := keymap.Deadline(); !ok {
+		t.Fatal("no deadline while a sequence is pending")
+	}
+	keymap.Flush()
+	if _, ok := keymap.Deadline(); ok {
+		t.Error("deadline after Flush")
+	}
+
+	// a key pressed after the timeout starts a new sequence
+	keymap.Dispatch(Event{Type: KeyboardEvent, ID: "d"})
+	time.Sleep(20 * time.Millisecond)
+	keymap.Dispatch(Event{Type: KeyboardEvent, ID: "d"})
+	keymap.Dispatch(Event{Type: KeyboardEvent, ID: "d"})
+	if want := []string{"d", "d", "dd"}; !reflect.DeepEqual(called, want) {
+		t.Errorf("got calls %q, want %q", called, want)
+	}
+}
+
+func TestKeymapRebindAndHelp(t *testing.T) {
+	keymap := NewKeymap()
+	keymap.Bind("", "q", "quit", func(Event) {})
+	keymap.Bind("", "q", "close", func(Event) {})
+	keymap.Bind("insert", "<Escape>", "leave insert mode", func(Event) {})
+
+	bindings := keymap.Bindings()
+	if len(bindings) != 2 || bindings[0].Description != "close" || bindings[1].Mode != "insert" {
+		t.Fatalf("got bindings %+v", bindings)
+	}
+	help := keymap.Help()
+	for _, want := range []string{"all modes:", "q", "close", "insert", "<Escape>", "leave insert mode"} {
+		if !strings.Contains(help, want) {
+			t.Errorf("help does not contain %q:\n%s", want, help)
+		}
+	}
+	if strings.Contains(help, "quit") {
+		t.Errorf("help contains the replaced binding:\n%s", help)
+	}
+}
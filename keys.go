@@ -0,0 +1,200 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"strconv"
+	"strings"
+)
+
+// KeyCode identifies a key that does not produce a character.
+type KeyCode uint
+
+const (
+	// KeyRune is the KeyCode of keys that produce a character, which is found in Key.Rune.
+	KeyRune KeyCode = iota
+	KeyF1
+	KeyF2
+	KeyF3
+	KeyF4
+	KeyF5
+	KeyF6
+	KeyF7
+	KeyF8
+	KeyF9
+	KeyF10
+	KeyF11
+	KeyF12
+	KeyInsert
+	KeyDelete
+	KeyHome
+	KeyEnd
+	KeyPageUp
+	KeyPageDown
+	KeyUp
+	KeyDown
+	KeyLeft
+	KeyRight
+	KeyBackspace
+	KeyTab
+	KeyEnter
+	KeyEscape
+	KeySpace
+)
+
+var keyCodeNames = map[KeyCode]string{
+	KeyF1:        "<F1>",
+	KeyF2:        "<F2>",
+	KeyF3:        "<F3>",
+	KeyF4:        "<F4>",
+	KeyF5:        "<F5>",
+	KeyF6:        "<F6>",
+	KeyF7:        "<F7>",
+	KeyF8:        "<F8>",
+	KeyF9:        "<F9>",
+	KeyF10:       "<F10>",
+	KeyF11:       "<F11>",
+	KeyF12:       "<F12>",
+	KeyInsert:    "<Insert>",
+	KeyDelete:    "<Delete>",
+	KeyHome:      "<Home>",
+	KeyEnd:       "<End>",
+	KeyPageUp:    "<PageUp>",
+	KeyPageDown:  "<PageDown>",
+	KeyUp:        "<Up>",
+	KeyDown:      "<Down>",
+	KeyLeft:      "<Left>",
+	KeyRight:     "<Right>",
+	KeyBackspace: "<Backspace>",
+	KeyTab:       "<Tab>",
+	KeyEnter:     "<Enter>",
+	KeyEscape:    "<Escape>",
+	KeySpace:     "<Space>",
+}
+
+// Key payload.
+type Key struct {
+	Code  KeyCode
+	Rune  rune
+	Ctrl  bool
+	Alt   bool
+	Shift bool
+}
+
+// String returns the ID of keyboard events for the Key, like `j`, `<Up>`, `<C-a>` or `<M-<Up>>`.
+// Modifiers wrap the name of the key in the order Shift, Ctrl, Alt, like `<M-<C-<S-<Up>>>>`.
+func (self Key) String() string {
+	var id string
+	if self.Code == KeyRune {
+		if self.Rune == 0 {
+			return ""
+		}
+		id = string(self.Rune)
+	} else {
+		id = keyCodeNames[self.Code]
+	}
+	if self.Shift {
+		id = "<S-" + id + ">"
+	}
+	if self.Ctrl {
+		id = "<C-" + id + ">"
+	}
+	if self.Alt {
+		id = "<M-" + id + ">"
+	}
+	return id
+}
+
+// Event returns a keyboard Event for the Key.
+func (self Key) Event() Event {
+	return Event{
+		Type:    KeyboardEvent,
+		ID:      self.String(),
+		Payload: self,
+	}
+}
+
+// csiKeyCodes maps the final character of CSI and SS3 escape sequences to keys.
+var csiKeyCodes = map[rune]KeyCode{
+	'A': KeyUp,
+	'B': KeyDown,
+	'C': KeyRight,
+	'D': KeyLeft,
+	'H': KeyHome,
+	'F': KeyEnd,
+	'P': KeyF1,
+	'Q': KeyF2,
+	'R': KeyF3,
+	'S': KeyF4,
+}
+
+// csiTildeKeyCodes maps the first parameter of CSI escape sequences ending in `~` to keys.
+var csiTildeKeyCodes = map[int]KeyCode{
+	1:  KeyHome,
+	2:  KeyInsert,
+	3:  KeyDelete,
+	4:  KeyEnd,
+	5:  KeyPageUp,
+	6:  KeyPageDown,
+	7:  KeyHome,
+	8:  KeyEnd,
+	11: KeyF1,
+	12: KeyF2,
+	13: KeyF3,
+	14: KeyF4,
+	15: KeyF5,
+	17: KeyF6,
+	18: KeyF7,
+	19: KeyF8,
+	20: KeyF9,
+	21: KeyF10,
+	23: KeyF11,
+	24: KeyF12,
+}
+
+// decodeCSI decodes the key of an escape sequence like `ESC [ 1 ; 5 A` (Ctrl+Up) from its parameters and
+// final character. The modifier parameter is 1 plus a bitmask of Shift (1), Alt (2) and Ctrl (4).
+// Returns false if the sequence is not a known key.
+func decodeCSI(params string, final rune) (Key, bool) {
+	values := []int{}
+	if params != "" {
+		for _, param := range strings.Split(params, ";") {
+			value, err := strconv.Atoi(param)
+			if err != nil {
+				return Key{}, false
+			}
+			values = append(values, value)
+		}
+	}
+
+	var key Key
+	switch final {
+	case 'Z':
+		key = Key{Code: KeyTab, Shift: true}
+	case '~':
+		if len(values) == 0 {
+			return Key{}, false
+		}
+		code, ok := csiTildeKeyCodes[values[0]]
+		if !ok {
+			return Key{}, false
+		}
+		key.Code = code
+	default:
+		code, ok := csiKeyCodes[final]
+		if !ok {
+			return Key{}, false
+		}
+		key.Code = code
+	}
+
+	if len(values) >= 2 && values[1] > 1 {
+		modifiers := values[1] - 1
+		key.Shift = modifiers&1 != 0
+		key.Alt = modifiers&2 != 0
+		key.Ctrl = modifiers&4 != 0
+	}
+	return key, true
+}
@@ -0,0 +1,64 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"testing"
+)
+
+func TestDecodeCSI(t *testing.T) {
+	tests := []struct {
+		params string
+		final  rune
+		want   string
+		ok     bool
+	}{
+		{"", 'A', "<Up>", true},
+		{"", 'D', "<Left>", true},
+		{"", 'Z', "<S-<Tab>>", true},
+		{"", 'P', "<F1>", true},
+		{"1;5", 'A', "<C-<Up>>", true},
+		{"1;2", 'C', "<S-<Right>>", true},
+		{"1;3", 'B', "<M-<Down>>", true},
+		{"1;6", 'D', "<C-<S-<Left>>>", true},
+		{"1;8", 'H', "<M-<C-<S-<Home>>>>", true},
+		{"1;1", 'F', "<End>", true},
+		{"3", '~', "<Delete>", true},
+		{"5;5", '~', "<C-<PageUp>>", true},
+		{"15", '~', "<F5>", true},
+		{"24;2", '~', "<S-<F12>>", true},
+		{"", '~', "", false},
+		{"16", '~', "", false},
+		{"200", '~', "", false},
+		{"", 'q', "", false},
+		{"1;x", 'A', "", false},
+	}
+	for _, test := range tests {
+		key, ok := decodeCSI(test.params, test.final)
+		if ok != test.ok || (ok && key.String() != test.want) {
+			t.Errorf("decodeCSI(%q, %q) = %q, %v, want %q, %v", test.params, test.final, key.String(), ok, test.want, test.ok)
+		}
+	}
+}
+
+func TestKeyString(t *testing.T) {
+	tests := []struct {
+		key  Key
+		want string
+	}{
+		{Key{Code: KeyRune, Rune: 'j'}, "j"},
+		{Key{Code: KeyRune, Rune: 'a', Ctrl: true}, "<C-a>"},
+		{Key{Code: KeyRune, Rune: 'x', Alt: true}, "<M-x>"},
+		{Key{Code: KeyEnter}, "<Enter>"},
+		{Key{Code: KeyTab, Shift: true}, "<S-<Tab>>"},
+		{Key{Code: KeyUp, Shift: true, Ctrl: true, Alt: true}, "<M-<C-<S-<Up>>>>"},
+		{Key{}, ""},
+	}
+	for _, test := range tests {
+		if got := test.key.String(); got != test.want {
+			t.Errorf("%#v.String() = %q, want %q", test.key, got, test.want)
+		}
+	}
+}
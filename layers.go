@@ -0,0 +1,165 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"sort"
+)
+
+// Layers is a Drawable that composes other Drawables on numbered layers, lowest first,
+// and then a stack of overlays such as dialogs, dropdowns and tooltips on top of them.
+// The area of each Drawable is cleared before it is drawn, so nothing underneath shows through.
+// Rendering Layers after popping an overlay restores whatever was underneath it.
+type Layers struct {
+	Block
+	layers   map[int][]Drawable
+	overlays []Drawable
+	modals   map[Drawable]bool
+}
+
+// NewLayers returns empty Layers. Like Grid, it should usually be given the size of the terminal.
+func NewLayers() *Layers {
+	self := &Layers{
+		Block:  *NewBlock(),
+		layers: make(map[int][]Drawable),
+		modals: make(map[Drawable]bool),
+	}
+	self.Border = false
+	return self
+}
+
+// Add adds Drawables to a layer, on top of the ones already there.
+func (self *Layers) Add(layer int, items ...Drawable) {
+	self.Lock()
+	defer self.Unlock()
+	self.layers[layer] = append(self.layers[layer], items...)
+}
+
+// Remove removes a Drawable from every layer and from the overlays.
+func (self *Layers) Remove(item Drawable) {
+	self.Lock()
+	defer self.Unlock()
+	for layer, items := range self.layers {
+		self.layers[layer] = removeDrawable(items, item)
+		if len(self.layers[layer]) == 0 {
+			delete(self.layers, layer)
+		}
+	}
+	self.overlays = removeDrawable(self.overlays, item)
+	delete(self.modals, item)
+}
+
+func removeDrawable(items []Drawable, item Drawable) []Drawable {
+	kept := items[:0]
+	for _, i := range items {
+		if i != item {
+			kept = append(kept, i)
+		}
+	}
+	return kept
+}
+
+// PushOverlay draws a Drawable on top of all layers and previously pushed overlays.
+func (self *Layers) PushOverlay(item Drawable) {
+	self.Lock()
+	defer self.Unlock()
+	self.overlays = append(self.overlays, item)
+}
+
+// PushModal centers a Drawable of the given size in the Layers and pushes it as a modal overlay.
+// Until it is popped, HitTest and DispatchMouse do not reach the Drawables underneath it, and an App
+// whose Root is the Layers sends keyboard events to it instead of its Focus.
+func (self *Layers) PushModal(item Drawable, width, height int) {
+	rect := self.GetRect()
+	x := rect.Min.X + (rect.Dx()-width)/2
+	y := rect.Min.Y + (rect.Dy()-height)/2
+	item.SetRect(x, y, x+width, y+height)
+
+	self.Lock()
+	defer self.Unlock()
+	self.overlays = append(self.overlays, item)
+	self.modals[item] = true
+}
+
+// PopOverlay removes and returns the topmost overlay, or nil if there is none.
+func (self *Layers) PopOverlay() Drawable {
+	self.Lock()
+	defer self.Unlock()
+	if len(self.overlays) == 0 {
+		return nil
+	}
+	item := self.overlays[len(self.overlays)-1]
+	self.overlays = self.overlays[:len(self.overlays)-1]
+	delete(self.modals, item)
+	return item
+}
+
+// TopOverlay returns the topmost overlay, or nil if there is none.
+func (self *Layers) TopOverlay() Drawable {
+	self.Lock()
+	defer self.Unlock()
+	if len(self.overlays) == 0 {
+		return nil
+	}
+	return self.overlays[len(self.overlays)-1]
+}
+
+// Modal returns the topmost overlay pushed with PushModal, or nil if there is none.
+func (self *Layers) Modal() Drawable {
+	self.Lock()
+	defer self.Unlock()
+	for i := len(self.overlays) - 1; i >= 0; i-- {
+		if self.modals[self.overlays[i]] {
+			return self.overlays[i]
+		}
+	}
+	return nil
+}
+
+// Drawables returns every Drawable in the order they are drawn, ending with the topmost overlay.
+func (self *Layers) Drawables() []Drawable {
+	self.Lock()
+	defer self.Unlock()
+	return self.drawables()
+}
+
+// Children implements the Container interface. While there is a modal overlay, only it and the overlays
+// above it are returned, so that the Drawables underneath cannot be clicked.
+func (self *Layers) Children() []Drawable {
+	self.Lock()
+	defer self.Unlock()
+	for i := len(self.overlays) - 1; i >= 0; i-- {
+		if self.modals[self.overlays[i]] {
+			return append([]Drawable{}, self.overlays[i:]...)
+		}
+	}
+	return self.drawables()
+}
+
+func (self *Layers) drawables() []Drawable {
+	layers := make([]int, 0, len(self.layers))
+	for layer := range self.layers {
+		layers = append(layers, layer)
+	}
+	sort.Ints(layers)
+
+	items := []Drawable{}
+	for _, layer := range layers {
+		items = append(items, self.layers[layer]...)
+	}
+	return append(items, self.overlays...)
+}
+
+// Draw implements the Drawable interface.
+func (self *Layers) Draw(buf *Buffer) {
+	self.Block.Draw(buf)
+	for _, item := range self.drawables() {
+		sub := buf.Sub(item.GetRect())
+		sub.Fill(CellClear, sub.Rectangle)
+		item.Lock()
+		item.Draw(sub)
+		item.Unlock()
+	}
+}
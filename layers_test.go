@@ -0,0 +1,124 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"image"
+	"reflect"
+	"testing"
+)
+
+// handlerBlock is a Focusable Block recording the IDs of the events it handles.
+type handlerBlock struct {
+	Block
+	events []string
+}
+
+func newHandlerBlock(x1, y1, x2, y2 int) *handlerBlock {
+	self := &handlerBlock{Block: *NewBlock()}
+	self.SetRect(x1, y1, x2, y2)
+	return self
+}
+
+func (self *handlerBlock) HandleEvent(e Event) bool {
+	self.events = append(self.events, e.ID)
+	return true
+}
+
+func click(x, y int) Event {
+	return Event{Type: MouseEvent, ID: "<MouseLeft>", Payload: Mouse{X: x, Y: y}}
+}
+
+func TestLayersModal(t *testing.T) {
+	below := newHandlerBlock(0, 0, 40, 10)
+	tooltip := newHandlerBlock(0, 0, 5, 2)
+	dialog := newHandlerBlock(0, 0, 0, 0)
+	layers := NewLayers()
+	layers.SetRect(0, 0, 40, 10)
+	layers.Add(0, below)
+
+	layers.PushOverlay(tooltip)
+	if got := HitTest(image.Pt(20, 1), layers); got != below {
+		t.Errorf("HitTest next to an overlay = %v, want the Drawable underneath", got)
+	}
+
+	layers.PushModal(dialog, 10, 4)
+	if got := dialog.GetRect(); got != image.Rect(15, 3, 25, 7) {
+		t.Errorf("modal is at %v, want it centered", got)
+	}
+	if layers.Modal() != dialog {
+		t.Errorf("Modal() = %v, want the dialog", layers.Modal())
+	}
+	if got := HitTest(image.Pt(20, 4), layers); got != dialog {
+		t.Errorf("HitTest in the modal = %v, want the modal", got)
+	}
+	for _, p := range []image.Point{image.Pt(1, 1), image.Pt(30, 8)} {
+		if got := HitTest(p, layers); got != layers {
+			t.Errorf("HitTest at %v outside of the modal = %v, want the Layers", p, got)
+		}
+		if DispatchMouse(click(p.X, p.Y), layers) {
+			t.Errorf("click at %v outside of the modal was handled", p)
+		}
+	}
+	if !DispatchMouse(click(16, 4), layers) || !reflect.DeepEqual(dialog.events, []string{"<MouseLeft>"}) {
+		t.Errorf("click in the modal was not handled by it")
+	}
+	if len(below.events) != 0 || len(tooltip.events) != 0 {
+		t.Errorf("Drawables under the modal got events: %v %v", below.events, tooltip.events)
+	}
+	if len(layers.Drawables()) != 3 {
+		t.Errorf("Drawables() = %v, want the Drawables under the modal too", layers.Drawables())
+	}
+
+	// an overlay above the modal can still be clicked
+	layers.PushOverlay(tooltip)
+	if got := HitTest(image.Pt(1, 1), layers); got != tooltip {
+		t.Errorf("HitTest on an overlay above the modal = %v, want the overlay", got)
+	}
+	layers.PopOverlay()
+
+	if layers.PopOverlay() != dialog || layers.Modal() != nil {
+		t.Fatal("modal was not popped")
+	}
+	if got := HitTest(image.Pt(30, 8), layers); got != below {
+		t.Errorf("HitTest after popping the modal = %v, want the Drawable underneath", got)
+	}
+
+	layers.PushModal(dialog, 10, 4)
+	layers.Remove(dialog)
+	if layers.Modal() != nil {
+		t.Error("removed modal is still modal")
+	}
+}
+
+func TestAppModalKeys(t *testing.T) {
+	screen := NewHeadlessBackend(40, 10)
+	focused := newHandlerBlock(0, 0, 40, 10)
+	dialog := newHandlerBlock(0, 0, 0, 0)
+	layers := NewLayers()
+	layers.Add(0, focused)
+
+	app := NewApp(layers)
+	app.Backend = screen
+	app.Fullscreen = true
+	app.Focus = NewFocusChain(focused)
+	app.Handle("m", func(Event) { layers.PushModal(dialog, 10, 4) })
+	app.Handle("p", func(Event) { layers.PopOverlay() })
+	app.Handle("q", func(Event) { app.Stop() })
+	for _, id := range []string{"a", "m", "b", "p", "c", "q"} {
+		screen.PostEvent(Event{Type: KeyboardEvent, ID: id})
+	}
+	if err := app.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	// handlers are called first, so the modal gets the key that pushed it, and not the one that popped it
+	if want := []string{"a", "p", "c", "q"}; !reflect.DeepEqual(focused.events, want) {
+		t.Errorf("focused Drawable got %v, want %v", focused.events, want)
+	}
+	if want := []string{"m", "b"}; !reflect.DeepEqual(dialog.events, want) {
+		t.Errorf("modal got %v, want %v", dialog.events, want)
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"image"
+)
+
+// Container is implemented by Drawables that draw other Drawables, like Grid and Layers,
+// so that HitTest can find the Drawables inside them.
+type Container interface {
+	Drawable
+	// Children returns the Drawables inside the Container that can be hit, in the order they are drawn.
+	Children() []Drawable
+}
+
+// EventHandler is implemented by Drawables that handle events, like the widgets with a HandleEvent method.
+type EventHandler interface {
+	// HandleEvent returns whether the event was handled.
+	HandleEvent(Event) bool
+}
+
+// hitPath returns the Drawables under a point, from the outermost to the innermost.
+// Drawables are searched from the last drawn, since it is on top.
+func hitPath(p image.Point, items []Drawable) []Drawable {
+	for i := len(items) - 1; i >= 0; i-- {
+		item := items[i]
+		if !p.In(item.GetRect()) {
+			continue
+		}
+		path := []Drawable{item}
+		if container, ok := item.(Container); ok {
+			path = append(path, hitPath(p, container.Children())...)
+		}
+		return path
+	}
+	return nil
+}
+
+// HitTest returns the innermost Drawable under a point, looking inside Containers like Grid and Layers.
+// Returns nil if none of the Drawables contain the point.
+// Drawables are positioned when they are rendered, so HitTest should be called after Render.
+func HitTest(p image.Point, items ...Drawable) Drawable {
+	path := hitPath(p, items)
+	if len(path) == 0 {
+		return nil
+	}
+	return path[len(path)-1]
+}
+
+// relativeMouse returns a copy of the payload of a mouse event, with its positions made relative to a point.
+func relativeMouse(payload interface{}, min image.Point) interface{} {
+	switch mouse := payload.(type) {
+	case Mouse:
+		mouse.X -= min.X
+		mouse.Y -= min.Y
+		return mouse
+	case MouseDrag:
+		mouse.StartX -= min.X
+		mouse.StartY -= min.Y
+		mouse.X -= min.X
+		mouse.Y -= min.Y
+		return mouse
+	case MouseClick:
+		mouse.X -= min.X
+		mouse.Y -= min.Y
+		return mouse
+	}
+	return payload
+}
+
+// DispatchMouse sends a mouse event to the innermost Drawable under the cursor that implements EventHandler,
+// or to the Container it is in if it does not handle the event. The positions in the Mouse, MouseDrag or
+// MouseClick payload are made relative to the top left corner of the Drawable receiving the event.
+// Drag events are sent to the Drawable under the position where the drag started.
+// Returns whether the event was handled.
+func DispatchMouse(e Event, items ...Drawable) bool {
+	var p image.Point
+	switch mouse := e.Payload.(type) {
+	case Mouse:
+		p = image.Pt(mouse.X, mouse.Y)
+	case MouseDrag:
+		p = image.Pt(mouse.StartX, mouse.StartY)
+	case MouseClick:
+		p = image.Pt(mouse.X, mouse.Y)
+	default:
+		return false
+	}
+	path := hitPath(p, items)
+	for i := len(path) - 1; i >= 0; i-- {
+		handler, ok := path[i].(EventHandler)
+		if !ok {
+			continue
+		}
+		local := Event{e.Type, e.ID, relativeMouse(e.Payload, path[i].GetRect().Min)}
+		path[i].Lock()
+		handled := handler.HandleEvent(local)
+		path[i].Unlock()
+		if handled {
+			return true
+		}
+	}
+	return false
+}
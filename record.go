@@ -0,0 +1,285 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// recordingVersion is the version of the format written by Recorder.
+const recordingVersion = 1
+
+// recordingHeader is the first line of a recording.
+type recordingHeader struct {
+	Version   int   `json:"version"`
+	Width     int   `json:"width"`
+	Height    int   `json:"height"`
+	Timestamp int64 `json:"timestamp"`
+}
+
+// recordedEvent is a line of a recording after the header. Time is in seconds since the recording started.
+type recordedEvent struct {
+	Time        float64         `json:"time"`
+	Type        string          `json:"type"`
+	ID          string          `json:"id"`
+	PayloadType string          `json:"payload_type,omitempty"`
+	Payload     json.RawMessage `json:"payload,omitempty"`
+}
+
+var eventTypeNames = map[EventType]string{
+	KeyboardEvent: "keyboard",
+	MouseEvent:    "mouse",
+	ResizeEvent:   "resize",
+	ErrorEvent:    "error",
+	CustomEvent:   "custom",
+	PasteEvent:    "paste",
+}
+
+// Recorder writes the events of a session to a recording, one JSON object per line, so that the session can be
+// replayed with ReadRecording. The first line holds the size of the terminal, and each event is written with
+// the time since the recording started. The recording starts with Wrap or the first call to Record, which
+// must come after Init, so a Recorder can be given to App before it initializes termui.
+//
+// Payloads of the types defined by termui are decoded to the same types. Other payloads, like those of
+// CustomEvents, are recorded as JSON and decoded to maps, slices and basic types, or dropped if they cannot
+// be encoded.
+type Recorder struct {
+	w       io.Writer
+	start   time.Time
+	started bool
+	lock    sync.Mutex
+}
+
+// NewRecorder returns a Recorder writing to w.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{w: w}
+}
+
+// begin writes the header of the recording with the current size of the terminal, unless it is written already.
+func (self *Recorder) begin() error {
+	if self.started {
+		return nil
+	}
+	if backend == nil {
+		return errors.New("termui is not initialized")
+	}
+	width, height := TerminalDimensions()
+	self.start = time.Now()
+	header := recordingHeader{recordingVersion, width, height, self.start.Unix()}
+	if err := self.writeLine(header); err != nil {
+		return err
+	}
+	self.started = true
+	return nil
+}
+
+func (self *Recorder) writeLine(v interface{}) error {
+	line, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = self.w.Write(append(line, '\n'))
+	return err
+}
+
+// encodePayload returns the type and JSON of a payload for a recording.
+func encodePayload(payload interface{}) (string, json.RawMessage) {
+	var payloadType string
+	switch p := payload.(type) {
+	case nil:
+		return "", nil
+	case Key:
+		payloadType = "Key"
+	case Mouse:
+		payloadType = "Mouse"
+	case MouseDrag:
+		payloadType = "MouseDrag"
+	case MouseClick:
+		payloadType = "MouseClick"
+	case Resize:
+		payloadType = "Resize"
+	case string:
+		payloadType = "string"
+	case error:
+		payloadType = "error"
+		payload = p.Error()
+	default:
+		payloadType = "json"
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", nil
+	}
+	return payloadType, data
+}
+
+// decodePayload decodes a payload written by encodePayload.
+func decodePayload(payloadType string, data json.RawMessage) (interface{}, error) {
+	var err error
+	switch payloadType {
+	case "":
+		return nil, nil
+	case "Key":
+		var p Key
+		err = json.Unmarshal(data, &p)
+		return p, err
+	case "Mouse":
+		var p Mouse
+		err = json.Unmarshal(data, &p)
+		return p, err
+	case "MouseDrag":
+		var p MouseDrag
+		err = json.Unmarshal(data, &p)
+		return p, err
+	case "MouseClick":
+		var p MouseClick
+		err = json.Unmarshal(data, &p)
+		return p, err
+	case "Resize":
+		var p Resize
+		err = json.Unmarshal(data, &p)
+		return p, err
+	case "string":
+		var p string
+		err = json.Unmarshal(data, &p)
+		return p, err
+	case "error":
+		var p string
+		err = json.Unmarshal(data, &p)
+		return errors.New(p), err
+	case "json":
+		var p interface{}
+		err = json.Unmarshal(data, &p)
+		return p, err
+	}
+	return nil, fmt.Errorf("unknown payload type %q", payloadType)
+}
+
+// Record writes an event to the recording.
+func (self *Recorder) Record(e Event) error {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	if err := self.begin(); err != nil {
+		return err
+	}
+	payloadType, payload := encodePayload(e.Payload)
+	return self.writeLine(recordedEvent{
+		Time:        time.Since(self.start).Seconds(),
+		Type:        eventTypeNames[e.Type],
+		ID:          e.ID,
+		PayloadType: payloadType,
+		Payload:     payload,
+	})
+}
+
+// Wrap returns a channel with the events of another channel, like the one of PollEvents, recording each of them.
+// The recording starts right away. Events are still passed on if they cannot be written.
+func (self *Recorder) Wrap(events <-chan Event) <-chan Event {
+	self.lock.Lock()
+	self.begin()
+	self.lock.Unlock()
+	ch := make(chan Event)
+	go func() {
+		defer close(ch)
+		for e := range events {
+			self.Record(e)
+			ch <- e
+		}
+	}()
+	return ch
+}
+
+// RecordedEvent is an event of a Recording and the time it happened since the recording started.
+type RecordedEvent struct {
+	Time  time.Duration
+	Event Event
+}
+
+// Recording is a session recorded by a Recorder.
+type Recording struct {
+	Width     int
+	Height    int
+	Timestamp time.Time
+	Events    []RecordedEvent
+}
+
+// ReadRecording reads a recording written by a Recorder.
+func ReadRecording(r io.Reader) (*Recording, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("empty recording")
+	}
+	var header recordingHeader
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil {
+		return nil, fmt.Errorf("invalid recording header: %v", err)
+	}
+	if header.Version != recordingVersion {
+		return nil, fmt.Errorf("unsupported recording version %d", header.Version)
+	}
+
+	types := make(map[string]EventType)
+	for t, name := range eventTypeNames {
+		types[name] = t
+	}
+
+	self := &Recording{
+		Width:     header.Width,
+		Height:    header.Height,
+		Timestamp: time.Unix(header.Timestamp, 0),
+	}
+	for line := 2; scanner.Scan(); line++ {
+		var recorded recordedEvent
+		if err := json.Unmarshal(scanner.Bytes(), &recorded); err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		t, ok := types[recorded.Type]
+		if !ok {
+			return nil, fmt.Errorf("line %d: unknown event type %q", line, recorded.Type)
+		}
+		payload, err := decodePayload(recorded.PayloadType, recorded.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		self.Events = append(self.Events, RecordedEvent{
+			Time:  time.Duration(recorded.Time * float64(time.Second)),
+			Event: Event{t, recorded.ID, payload},
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return self, nil
+}
+
+// NewScreen returns a HeadlessBackend of the size of the recorded terminal, to replay the recording on.
+func (self *Recording) NewScreen() *HeadlessBackend {
+	return NewHeadlessBackend(self.Width, self.Height)
+}
+
+// Replay posts the recorded events to a HeadlessBackend with their recorded timing, sped up by a factor.
+// A speed of 0 or less posts them without waiting. Resize events resize the screen.
+func (self *Recording) Replay(screen *HeadlessBackend, speed float64) {
+	start := time.Now()
+	for _, recorded := range self.Events {
+		if speed > 0 {
+			time.Sleep(time.Until(start.Add(time.Duration(float64(recorded.Time) / speed))))
+		}
+		if resize, ok := recorded.Event.Payload.(Resize); ok && recorded.Event.Type == ResizeEvent {
+			screen.Resize(resize.Width, resize.Height)
+		} else {
+			screen.PostEvent(recorded.Event)
+		}
+	}
+}
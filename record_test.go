@@ -0,0 +1,127 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// recordedEvents are recorded and replayed by TestRecordingRoundTrip.
+var recordedEvents = []Event{
+	Key{Code: KeyRune, Rune: 'j', Ctrl: true}.Event(),
+	{Type: MouseEvent, ID: "<MouseLeft>", Payload: Mouse{X: 3, Y: 4, Drag: true, Shift: true}},
+	{Type: ResizeEvent, ID: "<Resize>", Payload: Resize{Width: 40, Height: 12}},
+	{Type: ErrorEvent, ID: "<Error>", Payload: errors.New("input lost")},
+	{Type: CustomEvent, ID: "progress", Payload: struct {
+		Name string `json:"name"`
+		Done []int  `json:"done"`
+	}{"build", []int{1, 2}}},
+	{Type: PasteEvent, ID: "<Paste>", Payload: "pasted\ntext"},
+	{Type: CustomEvent, ID: "tick"},
+}
+
+// wantReplayed returns the event replayed for a recorded event.
+func wantReplayed(e Event) Event {
+	switch p := e.Payload.(type) {
+	case error:
+		e.Payload = p.Error()
+	case Key, Mouse, Resize, string, nil:
+	default:
+		// other payloads are decoded from JSON
+		e.Payload = map[string]interface{}{"name": "build", "done": []interface{}{1.0, 2.0}}
+	}
+	return e
+}
+
+// replayedEvent makes errors comparable with wantReplayed.
+func replayedEvent(e Event) Event {
+	if err, ok := e.Payload.(error); ok {
+		e.Payload = err.Error()
+	}
+	return e
+}
+
+func TestRecordingRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := InitWithBackend(NewHeadlessBackend(30, 10)); err != nil {
+		t.Fatal(err)
+	}
+	recorder := NewRecorder(&buf)
+	for _, e := range recordedEvents {
+		if err := recorder.Record(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	Close()
+
+	recording, err := ReadRecording(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recording.Width != 30 || recording.Height != 10 {
+		t.Errorf("recorded a %dx%d terminal, want 30x10", recording.Width, recording.Height)
+	}
+	if len(recording.Events) != len(recordedEvents) {
+		t.Fatalf("read %d events, want %d", len(recording.Events), len(recordedEvents))
+	}
+	for i, recorded := range recording.Events {
+		if i > 0 && recorded.Time < recording.Events[i-1].Time {
+			t.Errorf("event %d is recorded before the previous one", i)
+		}
+		if got, want := replayedEvent(recorded.Event), wantReplayed(recordedEvents[i]); !reflect.DeepEqual(got, want) {
+			t.Errorf("read event %d as %#v, want %#v", i, got, want)
+		}
+	}
+
+	screen := recording.NewScreen()
+	if err := InitWithBackend(screen); err != nil {
+		t.Fatal(err)
+	}
+	defer Close()
+	events := PollEvents()
+	go recording.Replay(screen, 0)
+	for i, recorded := range recordedEvents {
+		select {
+		case e := <-events:
+			if got, want := replayedEvent(e), wantReplayed(recorded); !reflect.DeepEqual(got, want) {
+				t.Errorf("replayed event %d as %#v, want %#v", i, got, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("event %d was not replayed", i)
+		}
+	}
+	if width, height := TerminalDimensions(); width != 40 || height != 12 {
+		t.Errorf("replayed screen is %dx%d, want 40x12", width, height)
+	}
+}
+
+// TestAppRecorder checks that a Recorder given to an App before it runs records the size of the terminal.
+func TestAppRecorder(t *testing.T) {
+	var buf bytes.Buffer
+	screen := NewHeadlessBackend(25, 8)
+	app := NewApp(NewBlock())
+	app.Backend = screen
+	app.Recorder = NewRecorder(&buf)
+	app.Handle("q", func(Event) { app.Stop() })
+	screen.PostEvent(Event{Type: KeyboardEvent, ID: "q"})
+	if err := app.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	recording, err := ReadRecording(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recording.Width != 25 || recording.Height != 8 {
+		t.Errorf("recorded a %dx%d terminal, want 25x8", recording.Width, recording.Height)
+	}
+	if len(recording.Events) != 1 || recording.Events[0].Event.ID != "q" {
+		t.Errorf("recorded %v, want the q key press", recording.Events)
+	}
+}
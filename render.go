@@ -7,8 +7,6 @@ package termui
 import (
 	"image"
 	"sync"
-
-	tb "github.com/nsf/termbox-go"
 )
 
 type Drawable interface {
@@ -18,21 +16,139 @@ type Drawable interface {
 	sync.Locker
 }
 
+// syncer is implemented by backends that can repaint the whole terminal, discarding whatever is on screen.
+type syncer interface {
+	Sync() error
+}
+
+// clearer is implemented by backends that hold on to the size of the terminal until they are cleared.
+type clearer interface {
+	Clear() error
+}
+
+// cellUnknown marks a cell of the frame whose content on screen is unknown.
+var cellUnknown = Cell{Rune: -1}
+
+// frame holds the cells most recently sent to the backend, so that Render only emits the cells that changed.
+var frame struct {
+	sync.Mutex
+	image.Rectangle
+	cells []Cell
+	stale bool
+	dirty bool
+	// cleared is set when the backend cleared the terminal as it picked up a new size, so the next repaint
+	// does not need to clear it again
+	cleared bool
+}
+
+func init() {
+	frame.stale = true
+}
+
+// Invalidate discards the previously rendered frame, so the next call to Render repaints every cell.
+// It is called automatically when the terminal is resized.
+func Invalidate() {
+	frame.Lock()
+	frame.stale = true
+	frame.Unlock()
+}
+
+// resetFrame resizes the frame to the terminal and marks every cell as unknown.
+// frame must be locked.
+func resetFrame() {
+	clearBackend()
+	width, height := backend.Size()
+	frame.Rectangle = image.Rect(0, 0, width, height)
+	if cap(frame.cells) < width*height {
+		frame.cells = make([]Cell, width*height)
+	}
+	frame.cells = frame.cells[:width*height]
+	for i := range frame.cells {
+		frame.cells[i] = cellUnknown
+	}
+	if cast != nil {
+		cast.reset(frame.Size())
+	}
+}
+
+// clearBackend lets the backend pick up the size of the terminal.
+// frame must be locked.
+func clearBackend() {
+	c, ok := backend.(clearer)
+	if !ok {
+		return
+	}
+	width, height := backend.Size()
+	c.Clear()
+	if newWidth, newHeight := backend.Size(); newWidth != width || newHeight != height {
+		frame.cleared = true
+	}
+}
+
+// setFrameCell sends a cell to the backend if it differs from the one already on screen.
+// frame must be locked.
+func setFrameCell(p image.Point, c Cell) {
+	if !p.In(frame.Rectangle) {
+		return
+	}
+	i := p.Y*frame.Dx() + p.X
+	if frame.cells[i] == c {
+		return
+	}
+	frame.cells[i] = c
+	frame.dirty = true
+	backend.SetCell(p.X, p.Y, c)
+	if cast != nil {
+		cast.setCell(p, c)
+	}
+}
+
+// Frame returns a copy of the cells most recently rendered to the terminal.
+func Frame() *Buffer {
+	frame.Lock()
+	defer frame.Unlock()
+	buf := NewBuffer(frame.Rectangle)
+	for i, cell := range frame.cells {
+		if cell != cellUnknown {
+			buf.SetCell(cell, image.Pt(i%frame.Dx(), i/frame.Dx()))
+		}
+	}
+	return buf
+}
+
+// Render draws the items and flushes the cells that changed since the previous call to the terminal,
+// and to the asciicast recording if one was started with StartCastRecording.
 func Render(items ...Drawable) {
+	frame.Lock()
+	defer frame.Unlock()
+
+	repaint := frame.stale
+	if repaint {
+		resetFrame()
+		frame.stale = false
+	}
+
 	for _, item := range items {
 		buf := NewBuffer(item.GetRect())
 		item.Lock()
 		item.Draw(buf)
 		item.Unlock()
-		for point, cell := range buf.CellMap {
-			if point.In(buf.Rectangle) {
-				tb.SetCell(
-					point.X, point.Y,
-					cell.Rune,
-					tb.Attribute(cell.Style.Fg+1)|tb.Attribute(cell.Style.Modifier), tb.Attribute(cell.Style.Bg+1),
-				)
+		for y := buf.Min.Y; y < buf.Max.Y; y++ {
+			for x := buf.Min.X; x < buf.Max.X; x++ {
+				p := image.Pt(x, y)
+				setFrameCell(p, buf.GetCell(p))
 			}
 		}
 	}
-	tb.Flush()
+
+	if s, ok := backend.(syncer); ok && repaint && !frame.cleared {
+		s.Sync()
+	} else if frame.dirty {
+		backend.Flush()
+	}
+	frame.dirty = false
+	frame.cleared = false
+	if cast != nil {
+		cast.flush()
+	}
 }
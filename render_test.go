@@ -0,0 +1,86 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	ui "github.com/gizak/termui/v3"
+	"github.com/gizak/termui/v3/widgets"
+)
+
+// countingBackend counts the cells sent to a headless screen.
+type countingBackend struct {
+	*ui.HeadlessBackend
+	writes int
+}
+
+func (self *countingBackend) SetCell(x, y int, c ui.Cell) {
+	self.writes++
+	self.HeadlessBackend.SetCell(x, y, c)
+}
+
+// newGrid returns a full screen grid where only the sparkline changes between frames.
+func newGrid(width, height int) (*ui.Grid, *widgets.Sparkline) {
+	p := widgets.NewParagraph()
+	p.Title = "Static"
+	p.Text = "This paragraph never changes."
+
+	l := widgets.NewList()
+	l.Title = "Static"
+	for i := 0; i < 50; i++ {
+		l.Rows = append(l.Rows, fmt.Sprintf("row %d", i))
+	}
+
+	s := widgets.NewSparkline()
+	s.Data = make([]float64, width-2)
+	s.MaxVal = 2
+	s.MaxHeight = height
+	sg := widgets.NewSparklineGroup(s)
+	sg.Title = "Live"
+
+	grid := ui.NewGrid()
+	grid.SetRect(0, 0, width, height)
+	grid.Set(
+		ui.NewRow(0.8,
+			ui.NewCol(0.5, p),
+			ui.NewCol(0.5, l),
+		),
+		ui.NewRow(0.2, sg),
+	)
+	return grid, s
+}
+
+// benchmarkRender re-renders a mostly static grid and reports the cells written to the backend per frame.
+func benchmarkRender(b *testing.B, full bool) {
+	screen := &countingBackend{HeadlessBackend: ui.NewHeadlessBackend(200, 60)}
+	if err := ui.InitWithBackend(screen); err != nil {
+		b.Fatal(err)
+	}
+	defer ui.Close()
+	grid, s := newGrid(200, 60)
+	ui.Render(grid)
+
+	screen.writes = 0
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Data = append(s.Data[1:], math.Sin(float64(i)/5)+1)
+		if full {
+			ui.Invalidate()
+		}
+		ui.Render(grid)
+	}
+	b.ReportMetric(float64(screen.writes)/float64(b.N), "cells/frame")
+}
+
+func BenchmarkRenderDifferential(b *testing.B) {
+	benchmarkRender(b, false)
+}
+
+func BenchmarkRenderFullRepaint(b *testing.B) {
+	benchmarkRender(b, true)
+}
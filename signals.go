@@ -0,0 +1,127 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"runtime/debug"
+	"sync/atomic"
+	"syscall"
+)
+
+// suspender is implemented by backends that can give the terminal back to the shell for a while,
+// without closing.
+type suspender interface {
+	Suspend() error
+	Resume() error
+}
+
+var (
+	handlingSignals int32
+	suspending      int32
+)
+
+// HandleSignals makes termui restore the terminal when the program is stopped by a signal, and lets the
+// program be suspended like other terminal programs:
+//   - SIGINT, SIGTERM and SIGHUP call Close and exit the program
+//   - SIGTSTP and the <C-z> key, which terminals send instead of SIGTSTP while termui is running, call
+//     Suspend. <C-z> events are then not sent to PollEvents.
+//   - SIGCONT, when the program is continued after being stopped another way, repaints the terminal
+//
+// Signals are not supported on Windows, where HandleSignals only handles os.Interrupt and SIGTERM.
+func HandleSignals() {
+	if !atomic.CompareAndSwapInt32(&handlingSignals, 0, 1) {
+		return
+	}
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, append(append([]os.Signal{}, exitSignals...), suspendSignals...)...)
+	go func() {
+		for sig := range signals {
+			switch {
+			case isSuspendSignal(sig):
+				Suspend()
+			case isContinueSignal(sig):
+				if atomic.LoadInt32(&suspending) == 0 {
+					repaint()
+				}
+			default:
+				Close()
+				code := 1
+				if s, ok := sig.(syscall.Signal); ok {
+					code = 128 + int(s)
+				}
+				os.Exit(code)
+			}
+		}
+	}()
+}
+
+// suspendOnCtrlZ returns whether <C-z> events should call Suspend.
+func suspendOnCtrlZ() bool {
+	return atomic.LoadInt32(&handlingSignals) == 1 && canSuspend
+}
+
+// Suspend gives the terminal back to the shell and stops the program, like Ctrl-Z in other terminal programs.
+// When the program is continued, termui sets the terminal up again and posts a <Resize> event, and the next
+// Render repaints the whole terminal.
+func Suspend() error {
+	s, ok := backend.(suspender)
+	if !ok || !canSuspend {
+		return errors.New("termui: suspending is not supported")
+	}
+	atomic.StoreInt32(&suspending, 1)
+	defer atomic.StoreInt32(&suspending, 0)
+
+	// the frame lock keeps Render from using the backend while it is suspended or resuming
+	frame.Lock()
+	if err := s.Suspend(); err != nil {
+		frame.Unlock()
+		return err
+	}
+	stopErr := stopProcess()
+	err := s.Resume()
+	frame.Unlock()
+	if err != nil {
+		return err
+	}
+	repaint()
+	return stopErr
+}
+
+// repaint makes the next Render repaint the whole terminal, and posts a <Resize> event so that the program
+// renders again.
+func repaint() {
+	frame.Lock()
+	frame.stale = true
+	width, height := backend.Size()
+	frame.Unlock()
+	PostEvent(Event{
+		Type: ResizeEvent,
+		ID:   "<Resize>",
+		Payload: Resize{
+			Width:  width,
+			Height: height,
+		},
+	})
+}
+
+// CloseOnPanic calls Close if the goroutine is panicking, so that the panic is printed to a restored terminal,
+// then exits the program like an unrecovered panic. The deferred Close of main already runs when main panics,
+// but goroutines that render or handle events should defer CloseOnPanic:
+//
+//	go func() {
+//		defer ui.CloseOnPanic()
+//		...
+//	}()
+func CloseOnPanic() {
+	if r := recover(); r != nil {
+		Close()
+		fmt.Fprintf(os.Stderr, "panic: %v\n\n%s", r, debug.Stack())
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build !windows
+
+package termui
+
+import (
+	"os"
+	"syscall"
+)
+
+const canSuspend = true
+
+var (
+	exitSignals    = []os.Signal{syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP}
+	suspendSignals = []os.Signal{syscall.SIGTSTP, syscall.SIGCONT}
+)
+
+func isSuspendSignal(sig os.Signal) bool {
+	return sig == syscall.SIGTSTP
+}
+
+func isContinueSignal(sig os.Signal) bool {
+	return sig == syscall.SIGCONT
+}
+
+// stopProcess stops the program until it is continued by the shell.
+func stopProcess() error {
+	return syscall.Kill(syscall.Getpid(), syscall.SIGSTOP)
+}
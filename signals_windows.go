@@ -0,0 +1,32 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build windows
+
+package termui
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+const canSuspend = false
+
+var (
+	exitSignals    = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	suspendSignals = []os.Signal{}
+)
+
+func isSuspendSignal(sig os.Signal) bool {
+	return false
+}
+
+func isContinueSignal(sig os.Signal) bool {
+	return false
+}
+
+func stopProcess() error {
+	return errors.New("termui: suspending is not supported on Windows")
+}
@@ -1,6 +1,6 @@
 package termui
 
-// Color is an integer from -1 to 255
+// Color is an integer from -1 to 255, or a 24-bit color created with NewRGBColor
 // -1 = ColorClear
 // 0-255 = Xterm colors
 type Color int
@@ -22,12 +22,21 @@ const (
 
 type Modifier uint
 
+// Modifiers can be combined with `|`.
+// Backends that cannot display a modifier ignore it; termbox-go cannot display strikethrough, and draws
+// double underlines as single underlines.
 const (
 	// ModifierClear clears any modifiers
-	ModifierClear     Modifier = 0
-	ModifierBold      Modifier = 1 << 9
-	ModifierUnderline Modifier = 1 << 10
-	ModifierReverse   Modifier = 1 << 11
+	ModifierClear           Modifier = 0
+	ModifierBold            Modifier = 1 << 9
+	ModifierUnderline       Modifier = 1 << 10
+	ModifierReverse         Modifier = 1 << 11
+	ModifierItalic          Modifier = 1 << 12
+	ModifierDim             Modifier = 1 << 13
+	ModifierStrikethrough   Modifier = 1 << 14
+	ModifierBlink           Modifier = 1 << 15
+	ModifierHidden          Modifier = 1 << 16
+	ModifierDoubleUnderline Modifier = 1 << 17
 )
 
 // Style represents the style of one terminal cell
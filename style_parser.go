@@ -5,6 +5,8 @@
 package termui
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -13,22 +15,17 @@ const (
 	tokenBg       = "bg"
 	tokenModifier = "mod"
 
-	tokenItemSeparator  = ","
-	tokenValueSeparator = ":"
+	tokenItemSeparator     = ","
+	tokenValueSeparator    = ":"
+	tokenModifierSeparator = "|"
 
 	tokenBeginStyledText = '['
 	tokenEndStyledText   = ']'
 
 	tokenBeginStyle = '('
 	tokenEndStyle   = ')'
-)
-
-type parserState uint
 
-const (
-	parserStateDefault parserState = iota
-	parserStateStyleItems
-	parserStateStyledText
+	tokenEscape = '\\'
 )
 
 // StyleParserColorMap can be modified to add custom color parsing to text
@@ -45,112 +42,180 @@ var StyleParserColorMap = map[string]Color{
 }
 
 var modifierMap = map[string]Modifier{
-	"bold":      ModifierBold,
-	"underline": ModifierUnderline,
-	"reverse":   ModifierReverse,
+	"bold":             ModifierBold,
+	"underline":        ModifierUnderline,
+	"reverse":          ModifierReverse,
+	"italic":           ModifierItalic,
+	"dim":              ModifierDim,
+	"strikethrough":    ModifierStrikethrough,
+	"blink":            ModifierBlink,
+	"hidden":           ModifierHidden,
+	"double-underline": ModifierDoubleUnderline,
 }
 
-// readStyle translates an []rune like `fg:red,mod:bold,bg:white` to a style
-func readStyle(runes []rune, defaultStyle Style) Style {
+// readColor translates a color name from StyleParserColorMap, a hex color like `#ff8800`,
+// or an Xterm color number like `208` to a Color.
+func readColor(s string) (Color, error) {
+	if color, ok := StyleParserColorMap[s]; ok {
+		return color, nil
+	}
+	if strings.HasPrefix(s, "#") {
+		return NewHexColor(s)
+	}
+	if n, err := strconv.Atoi(s); err == nil && n >= 0 && n < 256 {
+		return Color(n), nil
+	}
+	return ColorClear, fmt.Errorf("unknown color %q", s)
+}
+
+// readStyle translates an []rune like `fg:red,mod:bold|italic,bg:white` to a style, and returns whether any
+// of its items could be parsed. Items that cannot be parsed leave the corresponding field of defaultStyle
+// unchanged, unless strict is set, in which case an error is returned.
+func readStyle(runes []rune, defaultStyle Style, strict bool) (Style, bool, error) {
 	style := defaultStyle
-	split := strings.Split(string(runes), tokenItemSeparator)
-	for _, item := range split {
-		pair := strings.Split(item, tokenValueSeparator)
-		if len(pair) == 2 {
-			switch pair[0] {
-			case tokenFg:
-				style.Fg = StyleParserColorMap[pair[1]]
-			case tokenBg:
-				style.Bg = StyleParserColorMap[pair[1]]
-			case tokenModifier:
-				style.Modifier = modifierMap[pair[1]]
+	parsed := false
+	for _, item := range strings.Split(string(runes), tokenItemSeparator) {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		pair := strings.SplitN(item, tokenValueSeparator, 2)
+		if len(pair) != 2 {
+			if strict {
+				return style, parsed, fmt.Errorf("invalid style item %q", item)
+			}
+			continue
+		}
+		key, value := strings.TrimSpace(pair[0]), strings.TrimSpace(pair[1])
+		switch key {
+		case tokenFg, tokenBg:
+			color, err := readColor(value)
+			if err != nil {
+				if strict {
+					return style, parsed, err
+				}
+				continue
+			}
+			if key == tokenFg {
+				style.Fg = color
+			} else {
+				style.Bg = color
+			}
+			parsed = true
+		case tokenModifier:
+			style.Modifier = ModifierClear
+			for _, name := range strings.Split(value, tokenModifierSeparator) {
+				modifier, ok := modifierMap[strings.TrimSpace(name)]
+				if !ok && strict {
+					return style, parsed, fmt.Errorf("unknown modifier %q", name)
+				}
+				style.Modifier |= modifier
+			}
+			parsed = true
+		default:
+			if strict {
+				return style, parsed, fmt.Errorf("unknown style item %q", key)
 			}
 		}
 	}
-	return style
+	return style, parsed, nil
+}
+
+// isStyleToken returns whether a rune has to be escaped with a backslash to appear literally in styled text.
+func isStyleToken(r rune) bool {
+	switch r {
+	case tokenBeginStyledText, tokenEndStyledText, tokenBeginStyle, tokenEndStyle, tokenEscape:
+		return true
+	}
+	return false
+}
+
+// findClosing returns the index of the rune closing the one at runes[start], skipping nested pairs and,
+// if escapes is set, escaped runes. Returns -1 if there is none.
+func findClosing(runes []rune, start int, open, close rune, escapes bool) int {
+	depth := 0
+	for i := start; i < len(runes); i++ {
+		switch runes[i] {
+		case tokenEscape:
+			if escapes {
+				i++
+			}
+		case open:
+			depth++
+		case close:
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
 }
 
 // ParseStyles parses a string for embedded Styles and returns []Cell with the correct styling.
 // Uses defaultStyle for any text without an embedded style.
 // Syntax is of the form [text](fg:<color>,mod:<attribute>,bg:<color>).
-// Ordering does not matter. All fields are optional.
+// Ordering does not matter. All fields are optional. Colors can be names from StyleParserColorMap,
+// hex colors like `#ff8800` or Xterm color numbers like `208`. Several attributes can be combined like
+// `mod:bold|italic`. Styled text can be nested, in which case the inner text inherits the outer style.
+// `[`, `]`, `(`, `)` and `\` can be escaped with a backslash. A backslash at the end of styled text, like in
+// `[C:\](fg:red)`, is kept when the text would otherwise not be closed.
+// Styles that cannot be parsed are ignored, and text followed by parentheses without any style in them, like
+// `[link](http://example.com)`, is kept as is.
 func ParseStyles(s string, defaultStyle Style) []Cell {
-	cells := []Cell{}
-	runes := []rune(s)
-	state := parserStateDefault
-	styledText := []rune{}
-	styleItems := []rune{}
-	squareCount := 0
-
-	reset := func() {
-		styledText = []rune{}
-		styleItems = []rune{}
-		state = parserStateDefault
-		squareCount = 0
-	}
-
-	rollback := func() {
-		cells = append(cells, RunesToStyledCells(styledText, defaultStyle)...)
-		cells = append(cells, RunesToStyledCells(styleItems, defaultStyle)...)
-		reset()
-	}
+	cells, _ := parseStyles([]rune(s), defaultStyle, false)
+	return cells
+}
 
-	// chop first and last runes
-	chop := func(s []rune) []rune {
-		return s[1 : len(s)-1]
-	}
+// ParseStylesStrict is like ParseStyles, but returns an error for styles that cannot be parsed.
+func ParseStylesStrict(s string, defaultStyle Style) ([]Cell, error) {
+	return parseStyles([]rune(s), defaultStyle, true)
+}
 
-	for i, _rune := range runes {
-		switch state {
-		case parserStateDefault:
-			if _rune == tokenBeginStyledText {
-				state = parserStateStyledText
-				squareCount = 1
-				styledText = append(styledText, _rune)
-			} else {
-				cells = append(cells, Cell{_rune, defaultStyle})
+func parseStyles(runes []rune, style Style, strict bool) ([]Cell, error) {
+	cells := []Cell{}
+	for i := 0; i < len(runes); i++ {
+		_rune := runes[i]
+		switch {
+		case _rune == tokenEscape && i+1 < len(runes) && isStyleToken(runes[i+1]):
+			i++
+			cells = append(cells, Cell{runes[i], style})
+		case _rune == tokenBeginStyledText:
+			// text in brackets that is not followed by a style is kept as is
+			textEnd := findClosing(runes, i, tokenBeginStyledText, tokenEndStyledText, true)
+			if textEnd == -1 {
+				// the closing bracket may be escaped by a backslash that ends the text
+				textEnd = findClosing(runes, i, tokenBeginStyledText, tokenEndStyledText, false)
 			}
-		case parserStateStyledText:
-			switch {
-			case squareCount == 0:
-				switch _rune {
-				case tokenBeginStyle:
-					state = parserStateStyleItems
-					styleItems = append(styleItems, _rune)
-				default:
-					rollback()
-					switch _rune {
-					case tokenBeginStyledText:
-						state = parserStateStyledText
-						squareCount = 1
-						styleItems = append(styleItems, _rune)
-					default:
-						cells = append(cells, Cell{_rune, defaultStyle})
-					}
+			if textEnd == -1 || textEnd+1 == len(runes) || runes[textEnd+1] != tokenBeginStyle {
+				cells = append(cells, Cell{_rune, style})
+				continue
+			}
+			styleEnd := findClosing(runes, textEnd+1, tokenBeginStyle, tokenEndStyle, true)
+			if styleEnd == -1 {
+				if strict {
+					return cells, fmt.Errorf("unterminated style %q", string(runes[textEnd+1:]))
 				}
-			case len(runes) == i+1:
-				rollback()
-				styledText = append(styledText, _rune)
-			case _rune == tokenBeginStyledText:
-				squareCount++
-				styledText = append(styledText, _rune)
-			case _rune == tokenEndStyledText:
-				squareCount--
-				styledText = append(styledText, _rune)
-			default:
-				styledText = append(styledText, _rune)
+				cells = append(cells, Cell{_rune, style})
+				continue
+			}
+			textStyle, parsed, err := readStyle(runes[textEnd+2:styleEnd], style, strict)
+			if err != nil {
+				return cells, fmt.Errorf("invalid style %q: %v", string(runes[textEnd+1:styleEnd+1]), err)
+			}
+			if !parsed {
+				cells = append(cells, Cell{_rune, style})
+				continue
 			}
-		case parserStateStyleItems:
-			styleItems = append(styleItems, _rune)
-			if _rune == tokenEndStyle {
-				style := readStyle(chop(styleItems), defaultStyle)
-				cells = append(cells, RunesToStyledCells(chop(styledText), style)...)
-				reset()
-			} else if len(runes) == i+1 {
-				rollback()
+			text, err := parseStyles(runes[i+1:textEnd], textStyle, strict)
+			cells = append(cells, text...)
+			if err != nil {
+				return cells, err
 			}
+			i = styleEnd
+		default:
+			cells = append(cells, Cell{_rune, style})
 		}
 	}
-
-	return cells
+	return cells, nil
 }
@@ -0,0 +1,85 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"reflect"
+	"testing"
+)
+
+// span is a run of text in a single style.
+type span struct {
+	Text  string
+	Style Style
+}
+
+// spans groups cells into runs of the same style.
+func spans(cells []Cell) []span {
+	result := []span{}
+	for _, cell := range cells {
+		if n := len(result); n > 0 && result[n-1].Style == cell.Style {
+			result[n-1].Text += string(cell.Rune)
+		} else {
+			result = append(result, span{string(cell.Rune), cell.Style})
+		}
+	}
+	return result
+}
+
+func TestParseStyles(t *testing.T) {
+	red := NewStyle(ColorRed)
+	orange, _ := NewHexColor("#ff8800")
+	tests := []struct {
+		input string
+		want  []span
+	}{
+		{"plain", []span{{"plain", StyleClear}}},
+		{`\[x\]`, []span{{"[x]", StyleClear}}},
+		{`a\\b`, []span{{`a\b`, StyleClear}}},
+		{`\a`, []span{{`\a`, StyleClear}}},
+		{"[a](fg:red) b", []span{{"a", red}, {" b", StyleClear}}},
+		{"[a [b](fg:blue) c](fg:red)", []span{{"a ", red}, {"b", NewStyle(ColorBlue)}, {" c", red}}},
+		{"[a [b](bg:blue) c](fg:red)", []span{{"a ", red}, {"b", NewStyle(ColorRed, ColorBlue)}, {" c", red}}},
+		{`[a \] b](fg:red)`, []span{{"a ] b", red}}},
+		{`[C:\](fg:red)`, []span{{`C:\`, red}}},
+		{"[link](http://x)", []span{{"[link](http://x)", StyleClear}}},
+		{"[x]()", []span{{"[x]()", StyleClear}}},
+		{"[no style]", []span{{"[no style]", StyleClear}}},
+		{"[open](fg:red", []span{{"[open](fg:red", StyleClear}}},
+		{"[x](fg:#ff8800)", []span{{"x", NewStyle(orange)}}},
+		{"[x](fg:208)", []span{{"x", NewStyle(Color(208))}}},
+		{"[x](fg:red,mod:bold|italic)", []span{{"x", NewStyle(ColorRed, ColorClear, ModifierBold|ModifierItalic)}}},
+		{"[x](fg:nope,bg:red)", []span{{"x", NewStyle(ColorClear, ColorRed)}}},
+	}
+	for _, test := range tests {
+		got := spans(ParseStyles(test.input, StyleClear))
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("ParseStyles(%q) = %v, want %v", test.input, got, test.want)
+		}
+	}
+}
+
+func TestParseStylesStrict(t *testing.T) {
+	tests := []struct {
+		input string
+		valid bool
+	}{
+		{"[x](fg:red,mod:bold|italic)", true},
+		{`[C:\](fg:red)`, true},
+		{"[no style]", true},
+		{"[x](fg:nope)", false},
+		{"[x](mod:nope)", false},
+		{"[x](size:10)", false},
+		{"[link](http://x)", false},
+		{"[open](fg:red", false},
+		{"[a [b](fg:nope) c](fg:red)", false},
+	}
+	for _, test := range tests {
+		_, err := ParseStylesStrict(test.input, StyleClear)
+		if (err == nil) != test.valid {
+			t.Errorf("ParseStylesStrict(%q) returned error %v", test.input, err)
+		}
+	}
+}
@@ -0,0 +1,468 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	tb "github.com/nsf/termbox-go"
+)
+
+// TermboxBackend is the default Backend, built on termbox-go.
+type TermboxBackend struct {
+	// ColorDepth is the number of colors the terminal can display. Colors are converted to the nearest
+	// one available. If it is ColorDepthAuto, Init sets it with DetectColorDepth.
+	//
+	// termbox-go cannot mix palette and 24-bit colors: with ColorDepthTrueColor every Color is sent as
+	// RGB, so the basic and Xterm colors are drawn with the default Xterm palette instead of the colors
+	// of the terminal's theme. ColorDepthTrueColor is therefore only used when it is set here, and a
+	// detected truecolor terminal gets ColorDepth256, with RGB colors converted to the nearest Xterm color.
+	ColorDepth ColorDepth
+
+	events  chan tb.Event
+	done    chan struct{}
+	pending []tb.Event
+}
+
+func NewTermboxBackend() *TermboxBackend {
+	return &TermboxBackend{}
+}
+
+// Init implements the Backend interface.
+func (self *TermboxBackend) Init() error {
+	if err := self.initTermbox(); err != nil {
+		return err
+	}
+	self.events = make(chan tb.Event)
+	self.done = make(chan struct{})
+	self.pending = nil
+	go self.pollTermbox(self.events, self.done)
+	return nil
+}
+
+func (self *TermboxBackend) initTermbox() error {
+	if err := tb.Init(); err != nil {
+		return err
+	}
+	tb.SetInputMode(tb.InputEsc | tb.InputMouse)
+	if self.ColorDepth == ColorDepthAuto {
+		self.ColorDepth = DetectColorDepth()
+		if self.ColorDepth == ColorDepthTrueColor {
+			self.ColorDepth = ColorDepth256
+		}
+	}
+	switch self.ColorDepth {
+	case ColorDepthTrueColor:
+		tb.SetOutputMode(tb.OutputRGB)
+	case ColorDepth256:
+		tb.SetOutputMode(tb.Output256)
+	default:
+		tb.SetOutputMode(tb.OutputNormal)
+	}
+	writeTerminal(bracketedPasteOn)
+	return nil
+}
+
+// Suspend restores the terminal to its original state until Resume, without closing the backend.
+func (self *TermboxBackend) Suspend() error {
+	writeTerminal(bracketedPasteOff)
+	tb.Close()
+	return nil
+}
+
+// Resume sets the terminal up again after Suspend.
+func (self *TermboxBackend) Resume() error {
+	return self.initTermbox()
+}
+
+// Terminals surround pasted text with escape sequences in bracketed paste mode, so that it can be
+// told apart from typed keys.
+const (
+	bracketedPasteOn    = "\x1b[?2004h"
+	bracketedPasteOff   = "\x1b[?2004l"
+	bracketedPasteStart = "200"
+	bracketedPasteEnd   = "\x1b[201~"
+)
+
+// writeTerminal writes an escape sequence to the terminal that termbox-go draws to.
+// It does nothing where the terminal cannot be opened, like on Windows.
+func writeTerminal(sequence string) {
+	tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+	if err != nil {
+		return
+	}
+	defer tty.Close()
+	tty.WriteString(sequence)
+}
+
+// Close implements the Backend interface.
+func (self *TermboxBackend) Close() {
+	if self.done == nil {
+		return
+	}
+	select {
+	case <-self.done:
+		return
+	default:
+	}
+	close(self.done)
+	writeTerminal(bracketedPasteOff)
+	// pollTermbox returns to polling termbox once done is closed, so the interrupt is received
+	tb.Interrupt()
+	tb.Close()
+}
+
+// Size implements the Backend interface.
+// It does not write to the terminal: termbox-go picks up a new size on Clear, before a repaint.
+func (self *TermboxBackend) Size() (int, int) {
+	return tb.Size()
+}
+
+// SetCell implements the Backend interface.
+func (self *TermboxBackend) SetCell(x, y int, c Cell) {
+	tb.SetCell(
+		x, y,
+		c.Rune,
+		self.attribute(c.Style.Fg)|termboxModifiers(c.Style.Modifier), self.attribute(c.Style.Bg),
+	)
+}
+
+var termboxModifierMap = map[Modifier]tb.Attribute{
+	ModifierBold:            tb.AttrBold,
+	ModifierUnderline:       tb.AttrUnderline,
+	ModifierReverse:         tb.AttrReverse,
+	ModifierItalic:          tb.AttrCursive,
+	ModifierDim:             tb.AttrDim,
+	ModifierBlink:           tb.AttrBlink,
+	ModifierHidden:          tb.AttrHidden,
+	ModifierDoubleUnderline: tb.AttrUnderline,
+}
+
+// termboxModifiers converts Modifiers to termbox attributes, dropping those termbox cannot display.
+func termboxModifiers(modifier Modifier) tb.Attribute {
+	var attr tb.Attribute
+	if modifier == ModifierClear {
+		return attr
+	}
+	for m, a := range termboxModifierMap {
+		if modifier&m != 0 {
+			attr |= a
+		}
+	}
+	return attr
+}
+
+// attribute converts a Color to a termbox color attribute for the current output mode.
+func (self *TermboxBackend) attribute(color Color) tb.Attribute {
+	color = self.ColorDepth.Convert(color)
+	if color == ColorClear {
+		return tb.ColorDefault
+	}
+	if self.ColorDepth == ColorDepthTrueColor {
+		return tb.RGBToAttribute(color.RGB())
+	}
+	return tb.Attribute(color + 1)
+}
+
+// Flush implements the Backend interface.
+func (self *TermboxBackend) Flush() error {
+	return tb.Flush()
+}
+
+// Sync repaints the whole terminal.
+func (self *TermboxBackend) Sync() error {
+	return tb.Sync()
+}
+
+// Clear empties the cells waiting to be flushed and picks up the current size of the terminal, without
+// writing to it.
+func (self *TermboxBackend) Clear() error {
+	return tb.Clear(tb.ColorDefault, tb.ColorDefault)
+}
+
+// escapeTimeout is how long PollEvent waits for the rest of an escape sequence after an Escape key press.
+// Key presses that arrive sooner are combined with the Escape into a single key press with Alt.
+const escapeTimeout = 25 * time.Millisecond
+
+// PollEvent implements the Backend interface.
+// termbox-go reports escape sequences it does not know, like Shift+Tab or Ctrl+Up, as an Escape key press
+// followed by the rest of the sequence, so these are decoded here.
+func (self *TermboxBackend) PollEvent() Event {
+	e := self.nextEvent()
+	if e.Type == tb.EventKey && e.Key == tb.KeyEsc && e.Ch == 0 && e.Mod == 0 {
+		return self.readEscapeSequence()
+	}
+	return convertTermboxEvent(e)
+}
+
+// pollTermbox sends termbox events to a channel, so that they can be waited on with a timeout,
+// until it is interrupted after done is closed.
+func (self *TermboxBackend) pollTermbox(events chan<- tb.Event, done <-chan struct{}) {
+	read := termboxReader()
+	for {
+		e := read()
+		if e.Type == tb.EventInterrupt {
+			select {
+			case <-done:
+				return
+			default:
+				continue
+			}
+		}
+		select {
+		case events <- e:
+		case <-done:
+		}
+	}
+}
+
+// nextEvent returns the next termbox event, starting with those pushed back by readEscapeSequence.
+// It returns an EventInterrupt once the backend is closed.
+func (self *TermboxBackend) nextEvent() tb.Event {
+	if len(self.pending) > 0 {
+		e := self.pending[0]
+		self.pending = self.pending[1:]
+		return e
+	}
+	select {
+	case e := <-self.events:
+		return e
+	case <-self.done:
+		return tb.Event{Type: tb.EventInterrupt}
+	}
+}
+
+// nextEventWithin is like nextEvent, but gives up after escapeTimeout.
+func (self *TermboxBackend) nextEventWithin() (tb.Event, bool) {
+	if len(self.pending) > 0 {
+		return self.nextEvent(), true
+	}
+	select {
+	case e := <-self.events:
+		return e, true
+	case <-self.done:
+		return tb.Event{}, false
+	case <-time.After(escapeTimeout):
+		return tb.Event{}, false
+	}
+}
+
+// readPaste reads the events of pasted text, up to the end of the bracketed paste, into a PasteEvent.
+func (self *TermboxBackend) readPaste() Event {
+	var sb strings.Builder
+	for !strings.HasSuffix(sb.String(), bracketedPasteEnd) {
+		e := self.nextEvent()
+		if e.Type == tb.EventInterrupt {
+			break
+		}
+		if e.Type != tb.EventKey {
+			continue
+		}
+		switch {
+		case e.Ch != 0:
+			sb.WriteRune(e.Ch)
+		case e.Key == tb.KeyEnter || e.Key == tb.KeyCtrlJ:
+			sb.WriteByte('\n')
+		case e.Key <= tb.KeySpace || e.Key == tb.KeyBackspace2:
+			// control characters are reported with their ASCII code as the key
+			sb.WriteByte(byte(e.Key))
+		}
+	}
+	return Event{
+		Type:    PasteEvent,
+		ID:      "<Paste>",
+		Payload: strings.TrimSuffix(sb.String(), bracketedPasteEnd),
+	}
+}
+
+// readEscapeSequence reads the events following an Escape key press and decodes them.
+func (self *TermboxBackend) readEscapeSequence() Event {
+	escape := Key{Code: KeyEscape}.Event()
+
+	e, ok := self.nextEventWithin()
+	if !ok {
+		return escape
+	}
+	if e.Type != tb.EventKey || e.Key == tb.KeyEsc {
+		self.pending = append([]tb.Event{e}, self.pending...)
+		return escape
+	}
+
+	if e.Ch == '[' || e.Ch == 'O' {
+		read := []tb.Event{e}
+		params := []rune{}
+		for {
+			next, ok := self.nextEventWithin()
+			if !ok {
+				break
+			}
+			read = append(read, next)
+			if next.Type != tb.EventKey || next.Ch == 0 {
+				break
+			}
+			if next.Ch >= '@' && next.Ch <= '~' {
+				if key, ok := decodeCSI(string(params), next.Ch); ok {
+					return key.Event()
+				}
+				if string(params) == bracketedPasteStart && next.Ch == '~' {
+					return self.readPaste()
+				}
+				break
+			}
+			params = append(params, next.Ch)
+		}
+		if len(read) > 1 {
+			// not a known sequence, so it is delivered as it was read
+			self.pending = append(read, self.pending...)
+			return escape
+		}
+	}
+
+	key := convertTermboxKey(e)
+	key.Alt = true
+	return key.Event()
+}
+
+var keyboardMap = map[tb.Key]Key{
+	tb.KeyF1:         {Code: KeyF1},
+	tb.KeyF2:         {Code: KeyF2},
+	tb.KeyF3:         {Code: KeyF3},
+	tb.KeyF4:         {Code: KeyF4},
+	tb.KeyF5:         {Code: KeyF5},
+	tb.KeyF6:         {Code: KeyF6},
+	tb.KeyF7:         {Code: KeyF7},
+	tb.KeyF8:         {Code: KeyF8},
+	tb.KeyF9:         {Code: KeyF9},
+	tb.KeyF10:        {Code: KeyF10},
+	tb.KeyF11:        {Code: KeyF11},
+	tb.KeyF12:        {Code: KeyF12},
+	tb.KeyInsert:     {Code: KeyInsert},
+	tb.KeyDelete:     {Code: KeyDelete},
+	tb.KeyHome:       {Code: KeyHome},
+	tb.KeyEnd:        {Code: KeyEnd},
+	tb.KeyPgup:       {Code: KeyPageUp},
+	tb.KeyPgdn:       {Code: KeyPageDown},
+	tb.KeyArrowUp:    {Code: KeyUp},
+	tb.KeyArrowDown:  {Code: KeyDown},
+	tb.KeyArrowLeft:  {Code: KeyLeft},
+	tb.KeyArrowRight: {Code: KeyRight},
+
+	tb.KeyCtrlSpace:  {Code: KeySpace, Rune: ' ', Ctrl: true}, // tb.KeyCtrl2 tb.KeyCtrlTilde
+	tb.KeyCtrlA:      {Code: KeyRune, Rune: 'a', Ctrl: true},
+	tb.KeyCtrlB:      {Code: KeyRune, Rune: 'b', Ctrl: true},
+	tb.KeyCtrlC:      {Code: KeyRune, Rune: 'c', Ctrl: true},
+	tb.KeyCtrlD:      {Code: KeyRune, Rune: 'd', Ctrl: true},
+	tb.KeyCtrlE:      {Code: KeyRune, Rune: 'e', Ctrl: true},
+	tb.KeyCtrlF:      {Code: KeyRune, Rune: 'f', Ctrl: true},
+	tb.KeyCtrlG:      {Code: KeyRune, Rune: 'g', Ctrl: true},
+	tb.KeyCtrlJ:      {Code: KeyRune, Rune: 'j', Ctrl: true},
+	tb.KeyCtrlK:      {Code: KeyRune, Rune: 'k', Ctrl: true},
+	tb.KeyCtrlL:      {Code: KeyRune, Rune: 'l', Ctrl: true},
+	tb.KeyCtrlN:      {Code: KeyRune, Rune: 'n', Ctrl: true},
+	tb.KeyCtrlO:      {Code: KeyRune, Rune: 'o', Ctrl: true},
+	tb.KeyCtrlP:      {Code: KeyRune, Rune: 'p', Ctrl: true},
+	tb.KeyCtrlQ:      {Code: KeyRune, Rune: 'q', Ctrl: true},
+	tb.KeyCtrlR:      {Code: KeyRune, Rune: 'r', Ctrl: true},
+	tb.KeyCtrlS:      {Code: KeyRune, Rune: 's', Ctrl: true},
+	tb.KeyCtrlT:      {Code: KeyRune, Rune: 't', Ctrl: true},
+	tb.KeyCtrlU:      {Code: KeyRune, Rune: 'u', Ctrl: true},
+	tb.KeyCtrlV:      {Code: KeyRune, Rune: 'v', Ctrl: true},
+	tb.KeyCtrlW:      {Code: KeyRune, Rune: 'w', Ctrl: true},
+	tb.KeyCtrlX:      {Code: KeyRune, Rune: 'x', Ctrl: true},
+	tb.KeyCtrlY:      {Code: KeyRune, Rune: 'y', Ctrl: true},
+	tb.KeyCtrlZ:      {Code: KeyRune, Rune: 'z', Ctrl: true},
+	tb.KeyBackspace:  {Code: KeyBackspace, Ctrl: true},       // tb.KeyCtrlH
+	tb.KeyTab:        {Code: KeyTab},                         // tb.KeyCtrlI
+	tb.KeyEnter:      {Code: KeyEnter},                       // tb.KeyCtrlM
+	tb.KeyEsc:        {Code: KeyEscape},                      // tb.KeyCtrlLsqBracket tb.KeyCtrl3
+	tb.KeyCtrl4:      {Code: KeyRune, Rune: '4', Ctrl: true}, // tb.KeyCtrlBackslash
+	tb.KeyCtrl5:      {Code: KeyRune, Rune: '5', Ctrl: true}, // tb.KeyCtrlRsqBracket
+	tb.KeyCtrl6:      {Code: KeyRune, Rune: '6', Ctrl: true},
+	tb.KeyCtrl7:      {Code: KeyRune, Rune: '7', Ctrl: true}, // tb.KeyCtrlSlash tb.KeyCtrlUnderscore
+	tb.KeySpace:      {Code: KeySpace, Rune: ' '},
+	tb.KeyBackspace2: {Code: KeyBackspace}, // tb.KeyCtrl8
+}
+
+// convertTermboxKey converts a termbox keyboard event to a Key.
+func convertTermboxKey(e tb.Event) Key {
+	var key Key
+	if e.Ch != 0 {
+		key = Key{Code: KeyRune, Rune: e.Ch}
+	} else {
+		key = keyboardMap[e.Key]
+	}
+	key.Alt = e.Mod == tb.ModAlt
+	return key
+}
+
+// convertTermboxKeyboardEvent converts a termbox keyboard event to an Event with a Key payload.
+// The ID of the Event combines the modifiers and the key in a more friendly string format.
+func convertTermboxKeyboardEvent(e tb.Event) Event {
+	return convertTermboxKey(e).Event()
+}
+
+// termboxModShift and termboxModCtrl are the modifier keys of mouse events decoded by termboxReader, which
+// termbox-go has no constants for.
+const (
+	termboxModShift tb.Modifier = 1 << 6
+	termboxModCtrl  tb.Modifier = 1 << 7
+)
+
+var mouseButtonMap = map[tb.Key]string{
+	tb.MouseLeft:      "<MouseLeft>",
+	tb.MouseMiddle:    "<MouseMiddle>",
+	tb.MouseRight:     "<MouseRight>",
+	tb.MouseRelease:   "<MouseRelease>",
+	tb.MouseWheelUp:   "<MouseWheelUp>",
+	tb.MouseWheelDown: "<MouseWheelDown>",
+}
+
+func convertTermboxMouseEvent(e tb.Event) Event {
+	converted, ok := mouseButtonMap[e.Key]
+	if !ok {
+		converted = "Unknown_Mouse_Button"
+	}
+	Drag := e.Mod&tb.ModMotion != 0
+	return Event{
+		Type: MouseEvent,
+		ID:   converted,
+		Payload: Mouse{
+			X:     e.MouseX,
+			Y:     e.MouseY,
+			Drag:  Drag,
+			Ctrl:  e.Mod&termboxModCtrl != 0,
+			Alt:   e.Mod&tb.ModAlt != 0,
+			Shift: e.Mod&termboxModShift != 0,
+		},
+	}
+}
+
+// convertTermboxEvent turns a termbox event into a termui event.
+func convertTermboxEvent(e tb.Event) Event {
+	switch e.Type {
+	case tb.EventError:
+		return Event{
+			Type:    ErrorEvent,
+			ID:      "<Error>",
+			Payload: e.Err,
+		}
+	case tb.EventKey:
+		return convertTermboxKeyboardEvent(e)
+	case tb.EventMouse:
+		return convertTermboxMouseEvent(e)
+	case tb.EventResize:
+		return Event{
+			Type: ResizeEvent,
+			ID:   "<Resize>",
+			Payload: Resize{
+				Width:  e.Width,
+				Height: e.Height,
+			},
+		}
+	}
+	return Event{}
+}
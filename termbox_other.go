@@ -0,0 +1,122 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build !windows
+
+package termui
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	tb "github.com/nsf/termbox-go"
+)
+
+// sgrMousePrefix starts the xterm SGR mouse reports, like `\x1b[<0;12;5M`, that termbox-go turns on.
+const sgrMousePrefix = "\x1b[<"
+
+// termboxReader returns a function reading termbox events.
+// termbox-go decodes mouse reports without the modifier keys held during them, so the input is read raw,
+// SGR mouse reports are decoded here, and termbox-go decodes the rest.
+func termboxReader() func() tb.Event {
+	var input []byte
+	data := make([]byte, 256)
+	return func() tb.Event {
+		for {
+			if e, n := parseTermboxInput(input); n > 0 {
+				input = input[n:]
+				if e.Type != tb.EventNone {
+					return e
+				}
+				continue
+			}
+			e := tb.PollRawEvent(data)
+			if e.Type != tb.EventRaw {
+				return e
+			}
+			input = append(input, data[:e.N]...)
+		}
+	}
+}
+
+// parseTermboxInput decodes the event at the start of raw input. Returns the event and the number of bytes
+// it takes, which is 0 if the input is incomplete. Bytes that are not an event are returned as an EventNone.
+func parseTermboxInput(input []byte) (tb.Event, int) {
+	if bytes.HasPrefix(input, []byte(sgrMousePrefix)) {
+		end := len(sgrMousePrefix)
+		for end < len(input) && (input[end] >= '0' && input[end] <= '9' || input[end] == ';') {
+			end++
+		}
+		if end == len(input) {
+			return tb.Event{}, 0
+		}
+		if input[end] == 'M' || input[end] == 'm' {
+			if e, ok := decodeSGRMouse(string(input[len(sgrMousePrefix):end]), input[end] == 'm'); ok {
+				return e, end + 1
+			}
+		}
+	}
+
+	e := tb.ParseEvent(input)
+	if e.N == 0 && len(input) > 0 && utf8.FullRune(input) {
+		// not a valid rune
+		return tb.Event{Type: tb.EventNone}, 1
+	}
+	return e, e.N
+}
+
+// decodeSGRMouse decodes the parameters of an SGR mouse report, which are the button and modifier bits and
+// the 1-based position of the cursor, like termbox-go does, but keeps the modifier keys in Mod.
+// The report ends with `m` instead of `M` when a button is released.
+func decodeSGRMouse(params string, release bool) (tb.Event, bool) {
+	fields := strings.Split(params, ";")
+	if len(fields) != 3 {
+		return tb.Event{}, false
+	}
+	codes := make([]int, len(fields))
+	for i, field := range fields {
+		code, err := strconv.Atoi(field)
+		if err != nil {
+			return tb.Event{}, false
+		}
+		codes[i] = code
+	}
+
+	b := codes[0]
+	e := tb.Event{Type: tb.EventMouse, MouseX: codes[1] - 1, MouseY: codes[2] - 1}
+	switch b & 3 {
+	case 0:
+		e.Key = tb.MouseLeft
+		if b&64 != 0 {
+			e.Key = tb.MouseWheelUp
+		}
+	case 1:
+		e.Key = tb.MouseMiddle
+		if b&64 != 0 {
+			e.Key = tb.MouseWheelDown
+		}
+	case 2:
+		e.Key = tb.MouseRight
+	case 3:
+		e.Key = tb.MouseRelease
+	}
+	if release {
+		e.Key = tb.MouseRelease
+	}
+	if b&4 != 0 {
+		e.Mod |= termboxModShift
+	}
+	if b&8 != 0 {
+		e.Mod |= tb.ModAlt
+	}
+	if b&16 != 0 {
+		e.Mod |= termboxModCtrl
+	}
+	if b&32 != 0 {
+		e.Mod |= tb.ModMotion
+	}
+	return e, true
+}
@@ -0,0 +1,55 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build !windows
+
+package termui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTermboxInput(t *testing.T) {
+	tests := []struct {
+		input   string
+		id      string
+		payload interface{}
+		n       int
+	}{
+		{"\x1b[<0;12;5M", "<MouseLeft>", Mouse{X: 11, Y: 4}, 10},
+		{"\x1b[<2;1;1M", "<MouseRight>", Mouse{}, 9},
+		{"\x1b[<16;1;1M", "<MouseLeft>", Mouse{Ctrl: true}, 10},
+		{"\x1b[<12;1;1M", "<MouseLeft>", Mouse{Alt: true, Shift: true}, 10},
+		{"\x1b[<48;3;4M", "<MouseLeft>", Mouse{X: 2, Y: 3, Drag: true, Ctrl: true}, 10},
+		{"\x1b[<4;3;4m", "<MouseRelease>", Mouse{X: 2, Y: 3, Shift: true}, 9},
+		{"\x1b[<65;1;1M", "<MouseWheelDown>", Mouse{}, 10},
+		{"\x1b[<80;1;1M", "<MouseWheelUp>", Mouse{Ctrl: true}, 10},
+		{"\x1b[<0;1;1Mab", "<MouseLeft>", Mouse{}, 9},
+		{"a\x1b[<0;1;1M", "a", Key{Code: KeyRune, Rune: 'a'}, 1},
+		{"é", "é", Key{Code: KeyRune, Rune: 'é'}, 2},
+	}
+	for _, test := range tests {
+		e, n := parseTermboxInput([]byte(test.input))
+		got := convertTermboxEvent(e)
+		if got.ID != test.id || !reflect.DeepEqual(got.Payload, test.payload) || n != test.n {
+			t.Errorf("%q: got %q %#v taking %d bytes, want %q %#v taking %d", test.input, got.ID, got.Payload, n,
+				test.id, test.payload, test.n)
+		}
+	}
+}
+
+func TestParseTermboxInputIncomplete(t *testing.T) {
+	for _, input := range []string{"", "\x1b[<", "\x1b[<0;1", "\x1b[<0;1;1", "\xe2\x82"} {
+		if _, n := parseTermboxInput([]byte(input)); n != 0 {
+			t.Errorf("%q: took %d bytes of incomplete input", input, n)
+		}
+	}
+	// input that can never be completed is skipped
+	for _, input := range []string{"\xff", "\x1b[<0;1M", "\x1b[<0;1x"} {
+		if _, n := parseTermboxInput([]byte(input)); n == 0 {
+			t.Errorf("%q: waits for more input", input)
+		}
+	}
+}
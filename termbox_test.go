@@ -0,0 +1,89 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"reflect"
+	"testing"
+
+	tb "github.com/nsf/termbox-go"
+)
+
+// typed returns the termbox events of a string typed on the keyboard.
+func typed(s string) []tb.Event {
+	events := []tb.Event{}
+	for _, r := range s {
+		switch r {
+		case '\x1b':
+			events = append(events, tb.Event{Type: tb.EventKey, Key: tb.KeyEsc})
+		case '\r':
+			events = append(events, tb.Event{Type: tb.EventKey, Key: tb.KeyEnter})
+		default:
+			events = append(events, tb.Event{Type: tb.EventKey, Ch: r})
+		}
+	}
+	return events
+}
+
+// pollTyped returns the IDs of the events a TermboxBackend reads from the termbox events of a string.
+// The events all arrive at once, and the backend stops waiting for more after escapeTimeout.
+func pollTyped(s string) ([]string, []interface{}) {
+	events := typed(s)
+	backend := &TermboxBackend{
+		events: make(chan tb.Event, len(events)),
+		done:   make(chan struct{}),
+	}
+	for _, e := range events {
+		backend.events <- e
+	}
+
+	ids := []string{}
+	payloads := []interface{}{}
+	for len(backend.events) > 0 || len(backend.pending) > 0 {
+		e := backend.PollEvent()
+		ids = append(ids, e.ID)
+		payloads = append(payloads, e.Payload)
+	}
+	return ids, payloads
+}
+
+func TestTermboxEscapeSequences(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"a", []string{"a"}},
+		{"\x1b", []string{"<Escape>"}},
+		{"\x1b[Z", []string{"<S-<Tab>>"}},
+		{"\x1b[1;5A", []string{"<C-<Up>>"}},
+		{"\x1b[1;3D", []string{"<M-<Left>>"}},
+		{"\x1b[5;5~", []string{"<C-<PageUp>>"}},
+		{"\x1bOP", []string{"<F1>"}},
+		{"\x1bx", []string{"<M-x>"}},
+		{"\x1b\r", []string{"<M-<Enter>>"}},
+		{"\x1b\x1b", []string{"<Escape>", "<Escape>"}},
+		{"\x1b[Za", []string{"<S-<Tab>>", "a"}},
+		// unknown sequences are pushed back and delivered as they were read
+		{"\x1b[99q", []string{"<Escape>", "[", "9", "9", "q"}},
+		{"\x1b[1;5", []string{"<Escape>", "[", "1", ";", "5"}},
+		{"\x1b[", []string{"<M-[>"}},
+	}
+	for _, test := range tests {
+		got, _ := pollTyped(test.input)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("events of %q = %q, want %q", test.input, got, test.want)
+		}
+	}
+}
+
+func TestTermboxBracketedPaste(t *testing.T) {
+	ids, payloads := pollTyped("\x1b[200~[a](b)\rc\x1b[201~d")
+	if want := []string{"<Paste>", "d"}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("got events %q, want %q", ids, want)
+	}
+	if want := "[a](b)\nc"; payloads[0] != want {
+		t.Errorf("got paste %q, want %q", payloads[0], want)
+	}
+}
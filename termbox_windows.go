@@ -0,0 +1,17 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+// +build windows
+
+package termui
+
+import (
+	tb "github.com/nsf/termbox-go"
+)
+
+// termboxReader returns a function reading termbox events. termbox-go does not report the modifier keys held
+// during mouse actions on Windows.
+func termboxReader() func() tb.Event {
+	return tb.PollEvent
+}
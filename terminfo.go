@@ -0,0 +1,101 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package termui
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+const (
+	terminfoMagic      = 0432
+	terminfoMagic32Bit = 01036
+
+	// terminfoColorsIndex is the index of the `colors` capability among the numeric capabilities.
+	terminfoColorsIndex = 13
+)
+
+// terminfoDirs returns the directories searched for compiled terminfo entries, in order.
+func terminfoDirs() []string {
+	dirs := []string{}
+	if dir := os.Getenv("TERMINFO"); dir != "" {
+		dirs = append(dirs, dir)
+	}
+	if home := os.Getenv("HOME"); home != "" {
+		dirs = append(dirs, filepath.Join(home, ".terminfo"))
+	}
+	for _, dir := range filepath.SplitList(os.Getenv("TERMINFO_DIRS")) {
+		if dir != "" {
+			dirs = append(dirs, dir)
+		}
+	}
+	return append(dirs, "/etc/terminfo", "/lib/terminfo", "/usr/share/terminfo")
+}
+
+// readTerminfo returns the compiled terminfo entry for the given terminal.
+func readTerminfo(term string) ([]byte, error) {
+	var err error
+	for _, dir := range terminfoDirs() {
+		var data []byte
+		// entries are stored in a subdirectory named after their first letter, or its hex code on macOS
+		data, err = ioutil.ReadFile(filepath.Join(dir, term[:1], term))
+		if err == nil {
+			return data, nil
+		}
+		data, err = ioutil.ReadFile(filepath.Join(dir, strconv.FormatUint(uint64(term[0]), 16), term))
+		if err == nil {
+			return data, nil
+		}
+	}
+	return nil, err
+}
+
+// terminfoColors returns the number of colors supported by the terminal according to its terminfo entry,
+// or -1 if it is unknown.
+func terminfoColors(term string) int {
+	if term == "" {
+		return -1
+	}
+	data, err := readTerminfo(term)
+	if err != nil || len(data) < 12 {
+		return -1
+	}
+
+	header := make([]int, 6)
+	for i := range header {
+		header[i] = int(binary.LittleEndian.Uint16(data[2*i:]))
+	}
+	magic, namesSize, boolsCount, numbersCount := header[0], header[1], header[2], header[3]
+
+	numberSize := 2
+	switch magic {
+	case terminfoMagic:
+	case terminfoMagic32Bit:
+		numberSize = 4
+	default:
+		return -1
+	}
+	if numbersCount <= terminfoColorsIndex {
+		return -1
+	}
+
+	offset := 12 + namesSize + boolsCount
+	// numbers are aligned on an even byte
+	if offset%2 != 0 {
+		offset++
+	}
+	offset += terminfoColorsIndex * numberSize
+	if offset+numberSize > len(data) {
+		return -1
+	}
+
+	if numberSize == 4 {
+		return int(int32(binary.LittleEndian.Uint32(data[offset:])))
+	}
+	return int(int16(binary.LittleEndian.Uint16(data[offset:])))
+}
@@ -43,8 +43,9 @@ type RootTheme struct {
 }
 
 type BlockTheme struct {
-	Title  Style
-	Border Style
+	Title         Style
+	Border        Style
+	FocusedBorder Style
 }
 
 type BarChartTheme struct {
@@ -107,8 +108,9 @@ var Theme = RootTheme{
 	Default: NewStyle(ColorWhite),
 
 	Block: BlockTheme{
-		Title:  NewStyle(ColorWhite),
-		Border: NewStyle(ColorWhite),
+		Title:         NewStyle(ColorWhite),
+		Border:        NewStyle(ColorWhite),
+		FocusedBorder: NewStyle(ColorCyan),
 	},
 
 	BarChart: BarChartTheme{
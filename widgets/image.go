@@ -17,6 +17,10 @@ type Image struct {
 	Monochrome          bool
 	MonochromeThreshold uint8
 	MonochromeInvert    bool
+	// TrueColor draws each cell in the average color of its pixels instead of one of the 16 basic colors.
+	// It is drawn exactly on a TermboxBackend whose ColorDepth is ColorDepthTrueColor, and as the nearest
+	// Xterm color otherwise.
+	TrueColor bool
 }
 
 func NewImage(img image.Image) *Image {
@@ -93,10 +97,11 @@ func (self *Image) Draw(buf *Buffer) {
 					by*imageHeight/bufHeight,
 					(by+1)*imageHeight/bufHeight,
 				)
-				buf.SetCell(
-					NewCell(c.ch(), NewStyle(c.fgColor(), ColorBlack)),
-					image.Pt(self.Inner.Min.X+bx, self.Inner.Min.Y+by),
-				)
+				cell := NewCell(c.ch(), NewStyle(c.fgColor(), ColorBlack))
+				if self.TrueColor {
+					cell = NewCell(SHADED_BLOCKS[len(SHADED_BLOCKS)-1], NewStyle(c.rgbColor(), ColorBlack))
+				}
+				buf.SetCell(cell, image.Pt(self.Inner.Min.X+bx, self.Inner.Min.Y+by))
 			}
 		}
 	}
@@ -143,7 +148,12 @@ func (self colorAverager) RGBA() (uint32, uint32, uint32, uint32) {
 }
 
 func (self colorAverager) fgColor() Color {
-	return palette.Convert(self).(paletteColor).attribute
+	return ColorDepth16.Convert(self.rgbColor())
+}
+
+func (self colorAverager) rgbColor() Color {
+	c := color.RGBAModel.Convert(self).(color.RGBA)
+	return NewRGBColor(c.R, c.G, c.B)
 }
 
 func (self colorAverager) ch() rune {
@@ -166,26 +176,6 @@ func (self colorAverager) monochrome(threshold uint8, invert bool) bool {
 	return self.count != 0 && (color.GrayModel.Convert(self).(color.Gray).Y < threshold != invert)
 }
 
-type paletteColor struct {
-	rgba      color.RGBA
-	attribute Color
-}
-
-func (self paletteColor) RGBA() (uint32, uint32, uint32, uint32) {
-	return self.rgba.RGBA()
-}
-
-var palette = color.Palette([]color.Color{
-	paletteColor{color.RGBA{0, 0, 0, 255}, ColorBlack},
-	paletteColor{color.RGBA{255, 0, 0, 255}, ColorRed},
-	paletteColor{color.RGBA{0, 255, 0, 255}, ColorGreen},
-	paletteColor{color.RGBA{255, 255, 0, 255}, ColorYellow},
-	paletteColor{color.RGBA{0, 0, 255, 255}, ColorBlue},
-	paletteColor{color.RGBA{255, 0, 255, 255}, ColorMagenta},
-	paletteColor{color.RGBA{0, 255, 255, 255}, ColorCyan},
-	paletteColor{color.RGBA{255, 255, 255, 255}, ColorWhite},
-})
-
 func blocksChar(ul, ur, ll, lr colorAverager, threshold uint8, invert bool) rune {
 	index := 0
 	if ul.monochrome(threshold, invert) {
@@ -20,6 +20,8 @@ type List struct {
 	SelectedRow      int
 	topRow           int
 	SelectedRowStyle Style
+	// ANSI parses the rows for ANSI escape sequences with ParseANSI instead of ParseStyles.
+	ANSI bool
 }
 
 func NewList() *List {
@@ -42,9 +44,14 @@ func (self *List) Draw(buf *Buffer) {
 		self.topRow = self.SelectedRow
 	}
 
+	parse := ParseStyles
+	if self.ANSI {
+		parse = ParseANSI
+	}
+
 	// draw rows
 	for row := self.topRow; row < len(self.Rows) && point.Y < self.Inner.Max.Y; row++ {
-		cells := ParseStyles(self.Rows[row], self.TextStyle)
+		cells := parse(self.Rows[row], self.TextStyle)
 		if self.WrapText {
 			cells = WrapCells(cells, uint(self.Inner.Dx()))
 		}
@@ -89,7 +96,9 @@ func (self *List) Draw(buf *Buffer) {
 // There is no need to set self.topRow, as this will be set automatically when drawn,
 // since if the selected item is off screen then the topRow variable will change accordingly.
 func (self *List) ScrollAmount(amount int) {
-	if len(self.Rows)-int(self.SelectedRow) <= amount {
+	if len(self.Rows) == 0 {
+		self.SelectedRow = 0
+	} else if len(self.Rows)-int(self.SelectedRow) <= amount {
 		self.SelectedRow = len(self.Rows) - 1
 	} else if int(self.SelectedRow)+amount < 0 {
 		self.SelectedRow = 0
@@ -132,5 +141,23 @@ func (self *List) ScrollTop() {
 }
 
 func (self *List) ScrollBottom() {
-	self.SelectedRow = len(self.Rows) - 1
+	if len(self.Rows) > 0 {
+		self.SelectedRow = len(self.Rows) - 1
+	}
+}
+
+// HandleEvent implements the Focusable interface. The selected row is moved with the arrow, page,
+// Home and End keys, with j, k, G, <C-f>, <C-b>, <C-d> and <C-u>, and with the mouse wheel.
+// Clicking a row selects it, unless WrapText is set.
+func (self *List) HandleEvent(e Event) bool {
+	if len(self.Rows) == 0 {
+		return false
+	}
+	if row, ok := clickedRow(e, &self.Block, self.topRow, 1); ok && !self.WrapText {
+		if row < len(self.Rows) {
+			self.SelectedRow = row
+		}
+		return true
+	}
+	return handleScrollEvent(self, e)
 }
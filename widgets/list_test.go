@@ -0,0 +1,41 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"testing"
+
+	ui "github.com/gizak/termui/v3"
+)
+
+// scrollKeys are the keys that move the selected row of a List or a Table.
+var scrollKeys = []string{"j", "<Down>", "<PageDown>", "<C-d>", "<End>", "k", "<Up>", "<PageUp>", "<Home>"}
+
+// keyEvent returns the event of a key press with the given ID.
+func keyEvent(id string) ui.Event {
+	return ui.Event{Type: ui.KeyboardEvent, ID: id}
+}
+
+func TestListScrollEmpty(t *testing.T) {
+	for _, id := range scrollKeys {
+		list := NewList()
+		list.SetRect(0, 0, 20, 5)
+		list.HandleEvent(keyEvent(id))
+		list.ScrollDown()
+		list.ScrollPageDown()
+		list.ScrollBottom()
+		if list.SelectedRow != 0 {
+			t.Errorf("%s: SelectedRow of an empty List is %d", id, list.SelectedRow)
+		}
+		list.Draw(ui.NewBuffer(list.GetRect()))
+
+		list.Rows = []string{"a", "b"}
+		list.Draw(ui.NewBuffer(list.GetRect()))
+		list.HandleEvent(keyEvent("<Down>"))
+		if list.SelectedRow != 1 {
+			t.Errorf("%s: SelectedRow is %d after adding rows and scrolling down, want 1", id, list.SelectedRow)
+		}
+	}
+}
@@ -15,6 +15,8 @@ type Paragraph struct {
 	Text      string
 	TextStyle Style
 	WrapText  bool
+	// ANSI parses the text for ANSI escape sequences with ParseANSI instead of ParseStyles.
+	ANSI bool
 }
 
 func NewParagraph() *Paragraph {
@@ -28,7 +30,11 @@ func NewParagraph() *Paragraph {
 func (self *Paragraph) Draw(buf *Buffer) {
 	self.Block.Draw(buf)
 
-	cells := ParseStyles(self.Text, self.TextStyle)
+	parse := ParseStyles
+	if self.ANSI {
+		parse = ParseANSI
+	}
+	cells := parse(self.Text, self.TextStyle)
 	if self.WrapText {
 		cells = WrapCells(cells, uint(self.Inner.Dx()))
 	}
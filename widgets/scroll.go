@@ -0,0 +1,67 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"image"
+
+	. "github.com/gizak/termui/v3"
+)
+
+// scroller is implemented by widgets with a selected row, like List, Tree and Table.
+type scroller interface {
+	ScrollUp()
+	ScrollDown()
+	ScrollPageUp()
+	ScrollPageDown()
+	ScrollHalfPageUp()
+	ScrollHalfPageDown()
+	ScrollTop()
+	ScrollBottom()
+}
+
+// handleScrollEvent moves the selected row of a scroller on arrow, page and vim keys, and on the mouse wheel.
+// Returns whether the event was handled.
+func handleScrollEvent(s scroller, e Event) bool {
+	switch e.ID {
+	case "<Up>", "k", "<MouseWheelUp>":
+		s.ScrollUp()
+	case "<Down>", "j", "<MouseWheelDown>":
+		s.ScrollDown()
+	case "<PageUp>", "<C-b>":
+		s.ScrollPageUp()
+	case "<PageDown>", "<C-f>":
+		s.ScrollPageDown()
+	case "<C-u>":
+		s.ScrollHalfPageUp()
+	case "<C-d>":
+		s.ScrollHalfPageDown()
+	case "<Home>":
+		s.ScrollTop()
+	case "<End>", "G":
+		s.ScrollBottom()
+	default:
+		return false
+	}
+	return true
+}
+
+// clickedRow returns the row of a widget under a left click, given the Mouse payload relative to the widget,
+// the first row in view and the number of lines each row takes. Returns false if the click is not on a row.
+func clickedRow(e Event, block *Block, topRow, rowHeight int) (int, bool) {
+	mouse, ok := e.Payload.(Mouse)
+	if !ok || e.ID != "<MouseLeft>" {
+		return 0, false
+	}
+	p := image.Pt(mouse.X, mouse.Y).Add(block.Min)
+	if !p.In(block.Inner) {
+		return 0, false
+	}
+	line := p.Y - block.Inner.Min.Y
+	if line%rowHeight != 0 {
+		return 0, false
+	}
+	return topRow + line/rowHeight, true
+}
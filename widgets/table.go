@@ -29,6 +29,15 @@ type Table struct {
 	RowStyles     map[int]Style
 	FillRow       bool
 
+	// SelectedRow is kept in view when the Table has more rows than fit. It is drawn with
+	// SelectedRowStyle, unless that is StyleClear.
+	SelectedRow      int
+	SelectedRowStyle Style
+	topRow           int
+
+	// ANSI parses the cells for ANSI escape sequences with ParseANSI instead of ParseStyles.
+	ANSI bool
+
 	// ColumnResizer is called on each Draw. Can be used for custom column sizing.
 	ColumnResizer func()
 }
@@ -40,7 +49,17 @@ func NewTable() *Table {
 		RowSeparator:  true,
 		RowStyles:     make(map[int]Style),
 		ColumnResizer: func() {},
+
+		SelectedRowStyle: StyleClear,
+	}
+}
+
+// visibleRows returns how many rows fit in the Table.
+func (self *Table) visibleRows() int {
+	if self.RowSeparator {
+		return MaxInt((self.Inner.Dy()+1)/2, 1)
 	}
+	return MaxInt(self.Inner.Dy(), 1)
 }
 
 func (self *Table) Draw(buf *Buffer) {
@@ -57,10 +76,22 @@ func (self *Table) Draw(buf *Buffer) {
 		}
 	}
 
+	parse := ParseStyles
+	if self.ANSI {
+		parse = ParseANSI
+	}
+
+	// adjusts view into widget
+	if self.SelectedRow >= self.visibleRows()+self.topRow {
+		self.topRow = self.SelectedRow - self.visibleRows() + 1
+	} else if self.SelectedRow < self.topRow {
+		self.topRow = self.SelectedRow
+	}
+
 	yCoordinate := self.Inner.Min.Y
 
 	// draw rows
-	for i := 0; i < len(self.Rows) && yCoordinate < self.Inner.Max.Y; i++ {
+	for i := self.topRow; i < len(self.Rows) && yCoordinate < self.Inner.Max.Y; i++ {
 		row := self.Rows[i]
 		colXCoordinate := self.Inner.Min.X
 
@@ -69,6 +100,9 @@ func (self *Table) Draw(buf *Buffer) {
 		if style, ok := self.RowStyles[i]; ok {
 			rowStyle = style
 		}
+		if i == self.SelectedRow && self.SelectedRowStyle != StyleClear {
+			rowStyle = self.SelectedRowStyle
+		}
 
 		if self.FillRow {
 			blankCell := NewCell(' ', rowStyle)
@@ -77,7 +111,7 @@ func (self *Table) Draw(buf *Buffer) {
 
 		// draw row cells
 		for j := 0; j < len(row); j++ {
-			col := ParseStyles(row[j], rowStyle)
+			col := parse(row[j], rowStyle)
 			// draw row cell
 			if len(col) > columnWidths[j] || self.TextAlignment == AlignLeft {
 				for _, cx := range BuildCellWithXArray(col) {
@@ -134,3 +168,76 @@ func (self *Table) Draw(buf *Buffer) {
 		}
 	}
 }
+
+// ScrollAmount scrolls by amount given. If amount is < 0, then scroll up.
+// There is no need to set self.topRow, as this will be set automatically when drawn,
+// since if the selected row is off screen then the topRow variable will change accordingly.
+func (self *Table) ScrollAmount(amount int) {
+	if len(self.Rows) == 0 {
+		self.SelectedRow = 0
+	} else if len(self.Rows)-self.SelectedRow <= amount {
+		self.SelectedRow = len(self.Rows) - 1
+	} else if self.SelectedRow+amount < 0 {
+		self.SelectedRow = 0
+	} else {
+		self.SelectedRow += amount
+	}
+}
+
+func (self *Table) ScrollUp() {
+	self.ScrollAmount(-1)
+}
+
+func (self *Table) ScrollDown() {
+	self.ScrollAmount(1)
+}
+
+func (self *Table) ScrollPageUp() {
+	// If a row is selected below top row, then go to the top row.
+	if self.SelectedRow > self.topRow {
+		self.SelectedRow = self.topRow
+	} else {
+		self.ScrollAmount(-self.visibleRows())
+	}
+}
+
+func (self *Table) ScrollPageDown() {
+	self.ScrollAmount(self.visibleRows())
+}
+
+func (self *Table) ScrollHalfPageUp() {
+	self.ScrollAmount(-self.visibleRows() / 2)
+}
+
+func (self *Table) ScrollHalfPageDown() {
+	self.ScrollAmount(self.visibleRows() / 2)
+}
+
+func (self *Table) ScrollTop() {
+	self.SelectedRow = 0
+}
+
+func (self *Table) ScrollBottom() {
+	if len(self.Rows) > 0 {
+		self.SelectedRow = len(self.Rows) - 1
+	}
+}
+
+// HandleEvent implements the Focusable interface. The selected row is moved with the same keys as in List
+// and with the mouse wheel. Clicking a row selects it.
+func (self *Table) HandleEvent(e Event) bool {
+	if len(self.Rows) == 0 {
+		return false
+	}
+	rowHeight := 1
+	if self.RowSeparator {
+		rowHeight = 2
+	}
+	if row, ok := clickedRow(e, &self.Block, self.topRow, rowHeight); ok {
+		if row < len(self.Rows) {
+			self.SelectedRow = row
+		}
+		return true
+	}
+	return handleScrollEvent(self, e)
+}
@@ -0,0 +1,78 @@
+// Copyright 2017 Zack Guo <zack.y.guo@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT license that can
+// be found in the LICENSE file.
+
+package widgets
+
+import (
+	"flag"
+	"testing"
+
+	ui "github.com/gizak/termui/v3"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// checkGolden renders a widget to a headless screen and compares it to a golden file in testdata,
+// or writes the file with -update.
+func checkGolden(t *testing.T, name string, width, height int, item ui.Drawable) {
+	t.Helper()
+	screen := ui.NewHeadlessBackend(width, height)
+	if err := ui.InitWithBackend(screen); err != nil {
+		t.Fatal(err)
+	}
+	defer ui.Close()
+
+	item.SetRect(0, 0, width, height)
+	ui.Render(item)
+
+	path := "testdata/" + name + ".golden"
+	if *update {
+		if err := screen.WriteGolden(path); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	if err := screen.CompareGolden(path); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTableGolden(t *testing.T) {
+	table := NewTable()
+	table.Title = "Processes"
+	table.Rows = [][]string{
+		{"PID", "Command", "CPU"},
+		{"1", "init", "0.1"},
+		{"42", "termui", "12.5"},
+		{"1337", "[red](fg:red) text", "99.9"},
+	}
+	table.TextStyle = ui.NewStyle(ui.ColorWhite)
+	table.RowSeparator = true
+	table.RowStyles[0] = ui.NewStyle(ui.ColorYellow, ui.ColorClear, ui.ModifierBold)
+	table.SelectedRow = 2
+	table.SelectedRowStyle = ui.NewStyle(ui.ColorBlack, ui.ColorCyan)
+
+	checkGolden(t, "table", 32, 11, table)
+}
+
+func TestTableScrollEmpty(t *testing.T) {
+	for _, id := range scrollKeys {
+		table := NewTable()
+		table.SetRect(0, 0, 20, 5)
+		table.HandleEvent(keyEvent(id))
+		table.ScrollDown()
+		table.ScrollPageDown()
+		table.ScrollBottom()
+		if table.SelectedRow != 0 {
+			t.Errorf("%s: SelectedRow of an empty Table is %d", id, table.SelectedRow)
+		}
+
+		table.Rows = [][]string{{"a"}, {"b"}}
+		table.Draw(ui.NewBuffer(table.GetRect()))
+		table.HandleEvent(keyEvent("<Down>"))
+		if table.SelectedRow != 1 {
+			t.Errorf("%s: SelectedRow is %d after adding rows and scrolling down, want 1", id, table.SelectedRow)
+		}
+	}
+}
@@ -69,3 +69,33 @@ func (self *TabPane) Draw(buf *Buffer) {
 		xCoordinate += 2
 	}
 }
+
+// HandleEvent implements the Focusable interface. The active tab is changed with <Left>, <Right>, h and l,
+// and by clicking its name.
+func (self *TabPane) HandleEvent(e Event) bool {
+	if mouse, ok := e.Payload.(Mouse); ok && e.ID == "<MouseLeft>" {
+		p := image.Pt(mouse.X, mouse.Y).Add(self.Min)
+		if p.Y != self.Inner.Min.Y {
+			return false
+		}
+		// tabs are laid out as in Draw
+		xCoordinate := self.Inner.Min.X
+		for i, name := range self.TabNames {
+			if p.X >= xCoordinate && p.X < xCoordinate+len(name) {
+				self.ActiveTabIndex = i
+				return true
+			}
+			xCoordinate += 3 + len(name)
+		}
+		return false
+	}
+	switch e.ID {
+	case "<Left>", "h":
+		self.FocusLeft()
+	case "<Right>", "l":
+		self.FocusRight()
+	default:
+		return false
+	}
+	return true
+}
@@ -255,3 +255,31 @@ func (self *Tree) CollapseAll() {
 	})
 	self.prepareNodes()
 }
+
+// HandleEvent implements the Focusable interface. The selected node is moved with the same keys as in List
+// and with the mouse wheel, toggled with <Enter> and <Space>, expanded with <Right> and l, and collapsed with
+// <Left> and h. Clicking a node selects it, and clicking the selected node toggles it.
+func (self *Tree) HandleEvent(e Event) bool {
+	if len(self.rows) == 0 {
+		return false
+	}
+	if row, ok := clickedRow(e, &self.Block, self.topRow, 1); ok {
+		if row == self.SelectedRow {
+			self.ToggleExpand()
+		} else if row < len(self.rows) {
+			self.SelectedRow = row
+		}
+		return true
+	}
+	switch e.ID {
+	case "<Enter>", "<Space>":
+		self.ToggleExpand()
+	case "<Right>", "l":
+		self.Expand()
+	case "<Left>", "h":
+		self.Collapse()
+	default:
+		return handleScrollEvent(self, e)
+	}
+	return true
+}